@@ -0,0 +1,235 @@
+package cmd
+
+import (
+	"fmt"
+	"os"
+	"sort"
+	"strings"
+
+	"github.com/spf13/cobra"
+
+	"github.com/Parapheen/fullkek-starter/internal/config"
+	"github.com/Parapheen/fullkek-starter/internal/stacks"
+)
+
+// configFlags maps configuration keys onto the `new` command flags they default.
+var configFlags = map[string]string{
+	"frontend":        "frontend",
+	"styling":         "styling",
+	"http":            "http",
+	"database":        "database",
+	"auth":            "auth",
+	"oauth-providers": "oauth-providers",
+	"account":         "account",
+	"email":           "email",
+	"payments":        "payments",
+	"billing":         "billing",
+	"deploy":          "deploy",
+	"git":             "git",
+	"git-message":     "git-message",
+	"git-author":      "git-author",
+	"template-dirs":   "template-dir",
+}
+
+func newConfigCommand() *cobra.Command {
+	cmd := &cobra.Command{
+		Use:   "config",
+		Short: "Read and write default settings for fullkek commands.",
+		Long: fmt.Sprintf(`Read and write default settings for fullkek commands.
+
+Settings are resolved in this order, highest first:
+  command-line flags > %s* environment variables > ./%s > user config file > built-in defaults`, config.EnvPrefix, config.ProjectFileName),
+		RunE: func(cmd *cobra.Command, _ []string) error {
+			return cmd.Help()
+		},
+	}
+
+	cmd.AddCommand(newConfigGetCommand(), newConfigSetCommand(), newConfigListCommand())
+
+	return cmd
+}
+
+func newConfigGetCommand() *cobra.Command {
+	return &cobra.Command{
+		Use:   "get <key>",
+		Short: "Print the effective value of a setting.",
+		Args:  cobra.ExactArgs(1),
+		RunE: func(cmd *cobra.Command, args []string) error {
+			if _, ok := config.LookupKey(args[0]); !ok {
+				return fmt.Errorf("unknown config key %q", args[0])
+			}
+			cfg, err := loadConfig()
+			if err != nil {
+				return err
+			}
+			value, _ := cfg.Get(args[0])
+			fmt.Fprintln(cmd.OutOrStdout(), value)
+			return nil
+		},
+		ValidArgsFunction: completeConfigKeys,
+	}
+}
+
+func newConfigSetCommand() *cobra.Command {
+	var project bool
+
+	cmd := &cobra.Command{
+		Use:   "set <key> [value]",
+		Short: "Store a setting in the user config file (or ./.fullkekrc with --project). Omit value to unset.",
+		Args:  cobra.RangeArgs(1, 2),
+		RunE: func(cmd *cobra.Command, args []string) error {
+			var value string
+			if len(args) > 1 {
+				value = args[1]
+			}
+
+			path, err := configPath(project)
+			if err != nil {
+				return err
+			}
+			if err := config.Set(path, args[0], value); err != nil {
+				return err
+			}
+
+			if verbose(cmd) {
+				fmt.Fprintf(cmd.ErrOrStderr(), "Updated %s\n", path)
+			}
+			return nil
+		},
+		ValidArgsFunction: completeConfigKeys,
+	}
+
+	cmd.Flags().BoolVar(&project, "project", false, "write to "+config.ProjectFileName+" in the current directory")
+
+	return cmd
+}
+
+func newConfigListCommand() *cobra.Command {
+	var all bool
+
+	cmd := &cobra.Command{
+		Use:   "list",
+		Short: "List effective settings and where they come from.",
+		Args:  cobra.NoArgs,
+		RunE: func(cmd *cobra.Command, _ []string) error {
+			cfg, err := loadConfig()
+			if err != nil {
+				return err
+			}
+
+			settings := cfg.List()
+			out := cmd.OutOrStdout()
+			for _, key := range config.Keys() {
+				setting, ok := settings[key.Name]
+				if !ok {
+					if all {
+						fmt.Fprintf(out, "%s =\t# %s\n", key.Name, key.Description)
+					}
+					continue
+				}
+				fmt.Fprintf(out, "%s = %s\t(%s)\n", key.Name, setting.Value, setting.Source)
+			}
+			return nil
+		},
+	}
+
+	cmd.Flags().BoolVar(&all, "all", false, "include unset keys with their descriptions")
+
+	return cmd
+}
+
+func loadConfig() (config.Config, error) {
+	wd, err := os.Getwd()
+	if err != nil {
+		return config.Config{}, fmt.Errorf("resolve working directory: %w", err)
+	}
+	return config.Load(wd)
+}
+
+func configPath(project bool) (string, error) {
+	if project {
+		wd, err := os.Getwd()
+		if err != nil {
+			return "", fmt.Errorf("resolve working directory: %w", err)
+		}
+		return config.ProjectPath(wd), nil
+	}
+	return config.UserPath()
+}
+
+// applyConfigDefaults fills every flag the user did not pass explicitly from cfg.
+// A category key such as styling wins over a feature of that category listed
+// in presets.
+func applyConfigDefaults(cmd *cobra.Command, cfg config.Config) error {
+	names := make([]string, 0, len(configFlags))
+	for name := range configFlags {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+
+	for _, name := range names {
+		flagName := configFlags[name]
+		if cmd.Flags().Changed(flagName) {
+			continue
+		}
+		value, ok := cfg.Get(name)
+		if !ok {
+			continue
+		}
+		if err := cmd.Flags().Set(flagName, value); err != nil {
+			return fmt.Errorf("apply config %s: %w", name, err)
+		}
+	}
+
+	// Presets fill the feature flags that neither a flag nor a category key set.
+	if value, ok := cfg.Get(config.PresetsKey); ok {
+		byCategory := map[string][]string{}
+		var order []string
+		for _, id := range config.FeatureIDs(config.PresetsKey, value) {
+			feature, ok := stacks.FeatureByID(id)
+			if !ok {
+				return fmt.Errorf("apply config %s: unknown feature %q", config.PresetsKey, id)
+			}
+			if _, seen := byCategory[feature.CategoryID]; !seen {
+				order = append(order, feature.CategoryID)
+			}
+			byCategory[feature.CategoryID] = append(byCategory[feature.CategoryID], id)
+		}
+		for _, categoryID := range order {
+			flagName, ok := configFlags[categoryID]
+			if !ok || cmd.Flags().Changed(flagName) {
+				continue
+			}
+			if err := cmd.Flags().Set(flagName, strings.Join(byCategory[categoryID], ",")); err != nil {
+				return fmt.Errorf("apply config %s: %w", config.PresetsKey, err)
+			}
+		}
+	}
+
+	if !cmd.Flags().Changed("force") {
+		if value, ok := cfg.Get("conflict"); ok && value == "overwrite" {
+			if err := cmd.Flags().Set("force", "true"); err != nil {
+				return fmt.Errorf("apply config conflict: %w", err)
+			}
+		}
+	}
+
+	return nil
+}
+
+func completeConfigKeys(_ *cobra.Command, args []string, _ string) ([]string, cobra.ShellCompDirective) {
+	if len(args) > 0 {
+		return nil, cobra.ShellCompDirectiveNoFileComp
+	}
+	keys := config.Keys()
+	values := make([]string, 0, len(keys))
+	for _, key := range keys {
+		values = append(values, key.Name+"\t"+key.Description)
+	}
+	return values, cobra.ShellCompDirectiveNoFileComp
+}
+
+func modulePrefix(cfg config.Config) string {
+	prefix, _ := cfg.Get("module-prefix")
+	return strings.TrimRight(strings.TrimSpace(prefix), "/")
+}
@@ -0,0 +1,113 @@
+package cmd
+
+import (
+	"testing"
+
+	"github.com/Parapheen/fullkek-starter/internal/config"
+)
+
+func TestApplyConfigDefaultsHonoursPrecedence(t *testing.T) {
+	userDir := t.TempDir()
+	projectDir := t.TempDir()
+	t.Setenv("XDG_CONFIG_HOME", userDir)
+	t.Setenv("HOME", userDir)
+
+	userPath, err := config.UserPath()
+	if err != nil {
+		t.Fatalf("user path: %v", err)
+	}
+	projectPath := config.ProjectPath(projectDir)
+
+	// git-message is set in all four layers, git-author in three, styling in
+	// two and http in the user file only.
+	set := func(path, name, value string) {
+		t.Helper()
+		if err := config.Set(path, name, value); err != nil {
+			t.Fatalf("set %s in %s: %v", name, path, err)
+		}
+	}
+	set(userPath, "git-message", "from user")
+	set(userPath, "git-author", "User <user@example.com>")
+	set(userPath, "styling", "styling-tailwind")
+	set(userPath, "http", "http-chi")
+	set(projectPath, "git-message", "from project")
+	set(projectPath, "git-author", "Project <project@example.com>")
+	set(projectPath, "styling", "styling-daisyui")
+	t.Setenv(config.EnvName("git-message"), "from env")
+	t.Setenv(config.EnvName("git-author"), "Env <env@example.com>")
+
+	cmd := newNewCommand()
+	if err := cmd.ParseFlags([]string{"--git-message", "from flag"}); err != nil {
+		t.Fatalf("parse flags: %v", err)
+	}
+
+	cfg, err := config.Load(projectDir)
+	if err != nil {
+		t.Fatalf("load config: %v", err)
+	}
+	if err := applyConfigDefaults(cmd, cfg); err != nil {
+		t.Fatalf("apply config defaults: %v", err)
+	}
+
+	want := map[string]string{
+		"git-message": "from flag",
+		"git-author":  "Env <env@example.com>",
+		"styling":     "styling-daisyui",
+		"http":        "http-chi",
+	}
+	for flag, value := range want {
+		got, err := cmd.Flags().GetString(flag)
+		if err != nil {
+			t.Fatalf("get %s: %v", flag, err)
+		}
+		if got != value {
+			t.Fatalf("%s: expected %q, got %q", flag, value, got)
+		}
+	}
+}
+
+func TestApplyConfigDefaultsFillsFlagsFromPresets(t *testing.T) {
+	userDir := t.TempDir()
+	t.Setenv("XDG_CONFIG_HOME", userDir)
+	t.Setenv("HOME", userDir)
+
+	userPath, err := config.UserPath()
+	if err != nil {
+		t.Fatalf("user path: %v", err)
+	}
+	if err := config.Set(userPath, config.PresetsKey, "http-chi,styling-daisyui,deploy-ansible,account-2fa,account-roles"); err != nil {
+		t.Fatalf("set presets: %v", err)
+	}
+	if err := config.Set(userPath, "styling", "styling-tailwind-basecoat"); err != nil {
+		t.Fatalf("set styling: %v", err)
+	}
+
+	cmd := newNewCommand()
+	if err := cmd.ParseFlags([]string{"--deploy", "deploy-none"}); err != nil {
+		t.Fatalf("parse flags: %v", err)
+	}
+
+	cfg, err := config.Load(t.TempDir())
+	if err != nil {
+		t.Fatalf("load config: %v", err)
+	}
+	if err := applyConfigDefaults(cmd, cfg); err != nil {
+		t.Fatalf("apply config defaults: %v", err)
+	}
+
+	want := map[string]string{
+		"http":    "http-chi",
+		"styling": "styling-tailwind-basecoat",
+		"deploy":  "deploy-none",
+		"account": "account-2fa,account-roles",
+	}
+	for flag, value := range want {
+		got, err := cmd.Flags().GetString(flag)
+		if err != nil {
+			t.Fatalf("get %s: %v", flag, err)
+		}
+		if got != value {
+			t.Fatalf("%s: expected %q, got %q", flag, value, got)
+		}
+	}
+}
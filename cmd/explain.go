@@ -0,0 +1,201 @@
+package cmd
+
+import (
+	"context"
+	"fmt"
+	"io"
+	"path"
+	"sort"
+	"strings"
+
+	"github.com/spf13/cobra"
+
+	"github.com/Parapheen/fullkek-starter/internal/scaffold"
+	"github.com/Parapheen/fullkek-starter/internal/stacks"
+)
+
+func newExplainCommand() *cobra.Command {
+	var show string
+
+	cmd := &cobra.Command{
+		Use:   "explain <feature>",
+		Short: "Describe what a feature contributes to a generated project.",
+		Args:  cobra.ExactArgs(1),
+		RunE: func(cmd *cobra.Command, args []string) error {
+			feature, ok := stacks.FeatureByID(args[0])
+			if !ok {
+				return fmt.Errorf("unknown feature %q", args[0])
+			}
+
+			if show == "" {
+				printFeatureExplanation(cmd.OutOrStdout(), feature)
+				return nil
+			}
+
+			selection := exampleSelection(feature.ID)
+			stack, err := stacks.Compose(selection)
+			if err != nil {
+				return fmt.Errorf("compose example stack for %q: %w", feature.ID, err)
+			}
+
+			files, err := scaffold.DefaultGenerator().Render(context.Background(), "example", "example.com/example", stack)
+			if err != nil {
+				return err
+			}
+			target := path.Clean(strings.TrimPrefix(show, "./"))
+			for _, file := range files {
+				if file.Template.Destination == target {
+					_, err := cmd.OutOrStdout().Write(file.Content)
+					return err
+				}
+			}
+			return fmt.Errorf("%s is not generated by a stack including %q", show, feature.ID)
+		},
+		ValidArgsFunction: func(_ *cobra.Command, args []string, _ string) ([]string, cobra.ShellCompDirective) {
+			if len(args) > 0 {
+				return nil, cobra.ShellCompDirectiveNoFileComp
+			}
+			values := make([]string, 0)
+			for _, category := range stacks.Categories() {
+				for _, feature := range stacks.FeaturesForCategory(category.ID) {
+					values = append(values, feature.ID+"\t"+feature.Name)
+				}
+			}
+			return values, cobra.ShellCompDirectiveNoFileComp
+		},
+	}
+
+	cmd.Flags().StringVar(&show, "show", "", "render the given project file from an example stack that includes the feature")
+
+	return cmd
+}
+
+func printFeatureExplanation(out io.Writer, feature stacks.Feature) {
+	categoryName := feature.CategoryID
+	for _, category := range stacks.Categories() {
+		if category.ID == feature.CategoryID {
+			categoryName = category.Name
+		}
+	}
+
+	fmt.Fprintf(out, "%s — %s\n", feature.ID, feature.Name)
+	fmt.Fprintf(out, "Category: %s\n", categoryName)
+	fmt.Fprintf(out, "%s\n", feature.Description)
+
+	printList := func(title string, values []string) {
+		fmt.Fprintf(out, "\n%s:\n", title)
+		if len(values) == 0 {
+			fmt.Fprintln(out, "  (none)")
+			return
+		}
+		for _, value := range values {
+			fmt.Fprintf(out, "  - %s\n", value)
+		}
+	}
+
+	files := make([]string, 0, len(feature.Templates))
+	migrations := make([]string, 0)
+	for _, tmpl := range feature.Templates {
+		if strings.HasPrefix(tmpl.Destination, "db/migrations/") {
+			migrations = append(migrations, tmpl.Destination)
+			continue
+		}
+		files = append(files, tmpl.Destination)
+	}
+	sort.Strings(files)
+	sort.Strings(migrations)
+
+	requires := featureLabels(stacks.FeatureDependencies(feature.ID))
+	alternatives := stacks.FeatureAlternatives(feature.ID)
+	categoryIDs := make([]string, 0, len(alternatives))
+	for categoryID := range alternatives {
+		categoryIDs = append(categoryIDs, categoryID)
+	}
+	sort.Strings(categoryIDs)
+	for _, categoryID := range categoryIDs {
+		requires = append(requires, "one of "+strings.Join(alternatives[categoryID], ", "))
+	}
+	printList("Requires", requires)
+	printList("Required by", featureLabels(stacks.FeatureDependents(feature.ID)))
+	printList("Files", files)
+	printList("Directories", feature.Directories)
+	printList("Migrations", migrations)
+	printList("Routes", feature.Routes)
+	printList("Environment variables", feature.Env)
+	printList("Makefile targets", feature.MakeTargets)
+	printList("External tools", feature.Tools)
+}
+
+// featureLabels annotates feature IDs with their display names.
+func featureLabels(ids []string) []string {
+	out := make([]string, 0, len(ids))
+	for _, id := range ids {
+		if feature, ok := stacks.FeatureByID(id); ok {
+			out = append(out, fmt.Sprintf("%s (%s)", id, feature.Name))
+			continue
+		}
+		out = append(out, id)
+	}
+	return out
+}
+
+// exampleSelection returns the default selection extended with the feature and
+// everything it needs, so its templates can be rendered in context.
+func exampleSelection(featureID string) stacks.Selection {
+	selection := stacks.DefaultSelection()
+	categories := make(map[string]stacks.FeatureCategory)
+	for _, category := range stacks.Categories() {
+		categories[category.ID] = category
+	}
+
+	var include func(id string)
+	include = func(id string) {
+		feature, ok := stacks.FeatureByID(id)
+		if !ok {
+			return
+		}
+		current := selection[feature.CategoryID]
+		for _, existing := range current {
+			if existing == id {
+				return
+			}
+		}
+		if categories[feature.CategoryID].AllowMultiple {
+			kept := make([]string, 0, len(current)+1)
+			for _, existing := range current {
+				if !strings.HasSuffix(existing, "-none") {
+					kept = append(kept, existing)
+				}
+			}
+			selection[feature.CategoryID] = append(kept, id)
+		} else {
+			selection[feature.CategoryID] = []string{id}
+		}
+		for _, dep := range stacks.FeatureDependencies(id) {
+			include(dep)
+		}
+		for categoryID, alternatives := range stacks.FeatureAlternatives(id) {
+			if len(alternatives) > 0 && !containsAny(selection[categoryID], alternatives) {
+				include(alternatives[0])
+			}
+		}
+	}
+	include(featureID)
+
+	if containsAny(selection[stacks.CategoryAuth], []string{"auth-oauth2"}) && len(selection[stacks.CategoryOAuthProviders]) == 0 {
+		selection[stacks.CategoryOAuthProviders] = []string{"oauth-github"}
+	}
+
+	return selection
+}
+
+func containsAny(ids, candidates []string) bool {
+	for _, id := range ids {
+		for _, candidate := range candidates {
+			if id == candidate {
+				return true
+			}
+		}
+	}
+	return false
+}
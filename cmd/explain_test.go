@@ -0,0 +1,96 @@
+package cmd
+
+import (
+	"bytes"
+	"context"
+	"regexp"
+	"strings"
+	"testing"
+
+	"github.com/Parapheen/fullkek-starter/internal/scaffold"
+	"github.com/Parapheen/fullkek-starter/internal/stacks"
+)
+
+func TestExplainListsRoutesEnvAndMigrations(t *testing.T) {
+	t.Parallel()
+
+	root := RootCommand()
+	var out bytes.Buffer
+	root.SetOut(&out)
+	root.SetErr(&out)
+	root.SetArgs([]string{"explain", "payments-yookassa"})
+
+	if err := root.Execute(); err != nil {
+		t.Fatalf("explain: %v", err)
+	}
+
+	for _, want := range []string{
+		"database-sqlite (SQLite)",
+		"POST /webhooks/yookassa",
+		"YOOKASSA_SECRET_KEY",
+		"db/migrations/0004_create_payments.sql",
+	} {
+		if !strings.Contains(out.String(), want) {
+			t.Fatalf("expected %q in output, got:\n%s", want, out.String())
+		}
+	}
+}
+
+func TestExplainShowRendersFileWithDependencies(t *testing.T) {
+	t.Parallel()
+
+	root := RootCommand()
+	var out bytes.Buffer
+	root.SetOut(&out)
+	root.SetErr(&out)
+	root.SetArgs([]string{"explain", "oauth-google", "--show", "internal/app/app.go"})
+
+	if err := root.Execute(); err != nil {
+		t.Fatalf("explain --show: %v", err)
+	}
+	if !strings.Contains(out.String(), "NewGoogleProvider") {
+		t.Fatalf("expected rendered app.go to wire the Google provider, got:\n%s", out.String())
+	}
+}
+
+// TestExplainListsMatchRenderedProject keeps the hand-written explain lists in
+// step with the templates: every declared env var must appear in .env.example
+// and every declared Make target in the Makefile of a stack using the feature.
+func TestExplainListsMatchRenderedProject(t *testing.T) {
+	t.Parallel()
+
+	for _, category := range stacks.Categories() {
+		for _, feature := range stacks.FeaturesForCategory(category.ID) {
+			if len(feature.Env) == 0 && len(feature.MakeTargets) == 0 {
+				continue
+			}
+			t.Run(feature.ID, func(t *testing.T) {
+				t.Parallel()
+
+				stack, err := stacks.Compose(exampleSelection(feature.ID))
+				if err != nil {
+					t.Fatalf("compose example stack: %v", err)
+				}
+				files, err := scaffold.DefaultGenerator().Render(context.Background(), "example", "example.com/example", stack)
+				if err != nil {
+					t.Fatalf("render: %v", err)
+				}
+				rendered := make(map[string]string, len(files))
+				for _, file := range files {
+					rendered[file.Template.Destination] = string(file.Content)
+				}
+
+				for _, name := range feature.Env {
+					if !regexp.MustCompile(`(?m)^#?\s*` + regexp.QuoteMeta(name) + `=`).MatchString(rendered[".env.example"]) {
+						t.Errorf("env var %s is not in .env.example", name)
+					}
+				}
+				for _, target := range feature.MakeTargets {
+					if !regexp.MustCompile(`(?m)^` + regexp.QuoteMeta(target) + `:`).MatchString(rendered["Makefile"]) {
+						t.Errorf("make target %s is not in the Makefile", target)
+					}
+				}
+			})
+		}
+	}
+}
@@ -12,6 +12,7 @@ import (
 
 	"github.com/Parapheen/fullkek-starter/internal/scaffold"
 	"github.com/Parapheen/fullkek-starter/internal/stacks"
+	"github.com/Parapheen/fullkek-starter/internal/templates"
 	"github.com/Parapheen/fullkek-starter/internal/tui/newapp"
 	"github.com/Parapheen/fullkek-starter/internal/tui/output"
 )
@@ -30,9 +31,15 @@ func newNewCommand() *cobra.Command {
 		database       string
 		auth           string
 		oauthProviders string
+		account        string
 		email          string
 		payments       string
+		billing        string
 		deploy         string
+		git            string
+		gitMessage     string
+		gitAuthor      string
+		templateDirs   []string
 	}
 
 	frontendDefault := first(defaults[stacks.CategoryFrontend])
@@ -42,6 +49,7 @@ func newNewCommand() *cobra.Command {
 	authDefault := first(defaults[stacks.CategoryAuth])
 	emailDefault := first(defaults[stacks.CategoryEmail])
 	paymentsDefault := first(defaults[stacks.CategoryPayments])
+	billingDefault := first(defaults[stacks.CategoryBilling])
 	deployDefault := first(defaults[stacks.CategoryDeploy])
 
 	cmd := &cobra.Command{
@@ -49,6 +57,23 @@ func newNewCommand() *cobra.Command {
 		Short: "Create a new hypermedia project using modular feature blocks.",
 		Args:  cobra.RangeArgs(0, 1),
 		RunE: func(cmd *cobra.Command, args []string) error {
+			cfg, err := loadConfig()
+			if err != nil {
+				return err
+			}
+			if err := applyConfigDefaults(cmd, cfg); err != nil {
+				return err
+			}
+			prefix := modulePrefix(cfg)
+
+			gitMode, err := scaffold.ParseGitMode(opts.git)
+			if err != nil {
+				return err
+			}
+			if err := scaffold.ValidateCommitAuthor(opts.gitAuthor); err != nil {
+				return err
+			}
+
 			var appName string
 			if len(args) > 0 {
 				appName = args[0]
@@ -62,8 +87,10 @@ func newNewCommand() *cobra.Command {
 					stacks.CategoryHTTP:     opts.http,
 					stacks.CategoryDatabase: opts.database,
 					stacks.CategoryAuth:     opts.auth,
+					stacks.CategoryAccount:  opts.account,
 					stacks.CategoryEmail:    opts.email,
 					stacks.CategoryPayments: opts.payments,
+					stacks.CategoryBilling:  opts.billing,
 					stacks.CategoryDeploy:   opts.deploy,
 				}),
 			)
@@ -102,9 +129,14 @@ func newNewCommand() *cobra.Command {
 					featureChoices[category.ID] = stacks.FeaturesForCategory(category.ID)
 				}
 
+				wizardModulePath := opts.modulePath
+				if wizardModulePath == "" && appName != "" && prefix != "" {
+					wizardModulePath = deriveModulePath(appName, "", prefix)
+				}
+
 				wizardResult, err := newapp.Run(newapp.Options{
 					AppName:          appName,
-					ModulePath:       opts.modulePath,
+					ModulePath:       wizardModulePath,
 					OutputDir:        opts.outputDir,
 					Force:            opts.force,
 					Categories:       categories,
@@ -127,7 +159,7 @@ func newNewCommand() *cobra.Command {
 				if appName == "" {
 					return errors.New("app name required when not using interactive mode")
 				}
-				modulePath = deriveModulePath(appName, opts.modulePath)
+				modulePath = deriveModulePath(appName, opts.modulePath, prefix)
 				destination = opts.outputDir
 			}
 
@@ -139,7 +171,7 @@ func newNewCommand() *cobra.Command {
 				return errors.New("app name cannot be empty")
 			}
 			if modulePath == "" {
-				modulePath = deriveModulePath(appName, modulePath)
+				modulePath = deriveModulePath(appName, modulePath, prefix)
 			}
 			destination = deriveOutputDir(appName, destination)
 
@@ -149,6 +181,9 @@ func newNewCommand() *cobra.Command {
 			}
 
 			generator := scaffold.DefaultGenerator()
+			if len(opts.templateDirs) > 0 {
+				generator = scaffold.NewGenerator(scaffold.OverlayFS(templates.Files, opts.templateDirs...))
+			}
 			ctx := context.Background()
 
 			if verbose(cmd) {
@@ -161,6 +196,12 @@ func newNewCommand() *cobra.Command {
 				Destination: destination,
 				Stack:       stack,
 				Force:       force,
+				Git: scaffold.GitOptions{
+					Mode:          gitMode,
+					CommitMessage: opts.gitMessage,
+					CommitAuthor:  opts.gitAuthor,
+				},
+				Warnings: cmd.ErrOrStderr(),
 			}); err != nil {
 				return err
 			}
@@ -183,31 +224,53 @@ func newNewCommand() *cobra.Command {
 	cmd.Flags().StringVar(&opts.styling, "styling", stylingDefault, "styling feature identifier")
 	cmd.Flags().StringVar(&opts.http, "http", httpDefault, "HTTP framework feature identifier")
 	cmd.Flags().StringVar(&opts.database, "database", databaseDefault, "database feature identifier")
-	cmd.Flags().StringVar(&opts.auth, "auth", authDefault, "authentication feature identifier")
-	cmd.Flags().StringVar(&opts.oauthProviders, "oauth-providers", "", "comma-separated OAuth providers (github,google,yandex)")
+	cmd.Flags().StringVar(&opts.auth, "auth", authDefault, "authentication feature identifiers, comma-separated to combine (auth-oauth2,auth-password,auth-passkeys)")
+	cmd.Flags().StringVar(&opts.oauthProviders, "oauth-providers", "", "comma-separated OAuth providers (github,google,yandex,oidc)")
+	cmd.Flags().StringVar(&opts.account, "account", "", "comma-separated account feature identifiers (account-2fa,account-roles,account-tenancy,account-api-tokens)")
 	cmd.Flags().StringVar(&opts.email, "email", emailDefault, "email sending feature identifier")
 	cmd.Flags().StringVar(&opts.payments, "payments", paymentsDefault, "payment processing feature identifier")
+	cmd.Flags().StringVar(&opts.billing, "billing", billingDefault, "recurring billing feature identifier")
 	cmd.Flags().StringVar(&opts.deploy, "deploy", deployDefault, "deployment feature identifier")
+	cmd.Flags().StringSliceVar(&opts.templateDirs, "template-dir", nil, "directory whose files override the embedded templates (repeatable)")
+	cmd.Flags().StringVar(&opts.git, "git", string(scaffold.GitInit), "git integration: none, init, or commit")
+	cmd.Flags().StringVar(&opts.gitMessage, "git-message", scaffold.DefaultCommitMessage, "message for the initial commit (with --git=commit)")
+	cmd.Flags().StringVar(&opts.gitAuthor, "git-author", "", "author of the initial commit as \"Name <email>\" (with --git=commit)")
 
 	registerFeatureCompletion(cmd, "frontend", stacks.CategoryFrontend)
 	registerFeatureCompletion(cmd, "styling", stacks.CategoryStyling)
 	registerFeatureCompletion(cmd, "http", stacks.CategoryHTTP)
 	registerFeatureCompletion(cmd, "database", stacks.CategoryDatabase)
 	registerFeatureCompletion(cmd, "auth", stacks.CategoryAuth)
+	registerFeatureCompletion(cmd, "account", stacks.CategoryAccount)
 	registerFeatureCompletion(cmd, "email", stacks.CategoryEmail)
 	registerFeatureCompletion(cmd, "payments", stacks.CategoryPayments)
+	registerFeatureCompletion(cmd, "billing", stacks.CategoryBilling)
 	registerFeatureCompletion(cmd, "deploy", stacks.CategoryDeploy)
 
+	if err := cmd.RegisterFlagCompletionFunc("git", func(_ *cobra.Command, _ []string, _ string) ([]string, cobra.ShellCompDirective) {
+		modes := scaffold.GitModes()
+		values := make([]string, 0, len(modes))
+		for _, mode := range modes {
+			values = append(values, string(mode))
+		}
+		return values, cobra.ShellCompDirectiveNoFileComp
+	}); err != nil {
+		panic(err)
+	}
+
 	return cmd
 }
 
-func deriveModulePath(appName, override string) string {
+func deriveModulePath(appName, override, prefix string) string {
 	if override != "" {
 		return override
 	}
 	sanitized := strings.TrimSpace(appName)
 	sanitized = strings.ReplaceAll(sanitized, " ", "-")
 	sanitized = strings.ToLower(sanitized)
+	if prefix != "" {
+		return prefix + "/" + sanitized
+	}
 	return sanitized
 }
 
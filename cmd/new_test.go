@@ -2,6 +2,8 @@ package cmd
 
 import (
 	"bytes"
+	"os"
+	"path/filepath"
 	"strings"
 	"testing"
 )
@@ -57,3 +59,22 @@ func TestRootHelpDoesNotRenderBannerForNonTTY(t *testing.T) {
 		t.Fatalf("expected no banner in non-interactive output, got: %s", out.String())
 	}
 }
+
+func TestNewRejectsInvalidGitAuthorBeforeGenerating(t *testing.T) {
+	t.Parallel()
+
+	destination := filepath.Join(t.TempDir(), "my-app")
+	root := RootCommand()
+	var out bytes.Buffer
+	root.SetOut(&out)
+	root.SetErr(&out)
+	root.SetArgs([]string{"new", "my-app", "--no-ui", "--output", destination, "--git", "commit", "--git-author", "Nobody"})
+
+	err := root.Execute()
+	if err == nil || !strings.Contains(err.Error(), "invalid commit author") {
+		t.Fatalf("expected an invalid author error, got: %v", err)
+	}
+	if _, err := os.Stat(destination); !os.IsNotExist(err) {
+		t.Fatalf("expected nothing to be written, got: %v", err)
+	}
+}
@@ -0,0 +1,104 @@
+package cmd
+
+import (
+	"context"
+	"fmt"
+	"io"
+
+	"github.com/spf13/cobra"
+
+	"github.com/Parapheen/fullkek-starter/internal/scaffold"
+	"github.com/Parapheen/fullkek-starter/internal/stacks"
+)
+
+func newRemoveCommand() *cobra.Command {
+	var opts struct {
+		dir    string
+		force  bool
+		dryRun bool
+	}
+
+	cmd := &cobra.Command{
+		Use:   "remove <feature>",
+		Short: "Strip a feature from a generated project using its manifest.",
+		Long: `Strip a feature from a generated project using its manifest.
+
+Files owned only by the feature are deleted and shared files (app wiring,
+routers, .env, migrations) are re-rendered without it. Files edited since
+generation are left alone unless --force is given.`,
+		Args: cobra.ExactArgs(1),
+		RunE: func(cmd *cobra.Command, args []string) error {
+			generator := scaffold.DefaultGenerator()
+			ctx := context.Background()
+
+			plan, err := generator.PlanRemoval(ctx, opts.dir, args[0])
+			if err != nil {
+				return err
+			}
+
+			printRemovalPlan(cmd.OutOrStdout(), plan)
+			if opts.dryRun {
+				return nil
+			}
+
+			if err := generator.ApplyRemoval(ctx, opts.dir, plan, opts.force); err != nil {
+				return err
+			}
+
+			fmt.Fprintf(cmd.OutOrStdout(), "\nRemoved %s. Review the changes and rebuild.\n", plan.Feature.ID)
+			return nil
+		},
+		ValidArgsFunction: func(_ *cobra.Command, args []string, _ string) ([]string, cobra.ShellCompDirective) {
+			if len(args) > 0 {
+				return nil, cobra.ShellCompDirectiveNoFileComp
+			}
+			manifest, err := scaffold.ReadManifest(opts.dir)
+			if err != nil {
+				return nil, cobra.ShellCompDirectiveNoFileComp
+			}
+			values := make([]string, 0)
+			for _, ids := range manifest.Selection {
+				values = append(values, ids...)
+			}
+			return values, cobra.ShellCompDirectiveNoFileComp
+		},
+	}
+
+	cmd.Flags().StringVarP(&opts.dir, "dir", "C", ".", "project directory")
+	cmd.Flags().BoolVar(&opts.force, "force", false, "also delete or overwrite files edited since generation")
+	cmd.Flags().BoolVar(&opts.dryRun, "dry-run", false, "print the plan without changing any files")
+
+	return cmd
+}
+
+func printRemovalPlan(out io.Writer, plan scaffold.RemovalPlan) {
+	fmt.Fprintf(out, "Removing %s (%s)\n", plan.Feature.ID, plan.Feature.Name)
+	if plan.Replacement != "" {
+		if replacement, ok := stacks.FeatureByID(plan.Replacement); ok {
+			fmt.Fprintf(out, "Category falls back to %s (%s)\n", replacement.ID, replacement.Name)
+		}
+	}
+
+	modified := make(map[string]bool, len(plan.Modified))
+	for _, path := range plan.Modified {
+		modified[path] = true
+	}
+
+	printPaths := func(title string, paths []string) {
+		if len(paths) == 0 {
+			return
+		}
+		fmt.Fprintf(out, "\n%s:\n", title)
+		for _, path := range paths {
+			if modified[path] {
+				fmt.Fprintf(out, "  - %s (modified since generation)\n", path)
+				continue
+			}
+			fmt.Fprintf(out, "  - %s\n", path)
+		}
+	}
+
+	printPaths("Delete", plan.Delete)
+	printPaths("Re-render", plan.Rewrite)
+	printPaths("Create", plan.Create)
+}
@@ -40,6 +40,10 @@ build a project skeleton that is ready for templates, assets, and server wiring.
 	cmd.PersistentFlags().BoolP("verbose", "v", false, "enable verbose output")
 
 	cmd.AddCommand(newNewCommand())
+	cmd.AddCommand(newConfigCommand())
+	cmd.AddCommand(newRemoveCommand())
+	cmd.AddCommand(newExplainCommand())
+	cmd.AddCommand(newStatusCommand())
 
 	return cmd
 }
@@ -0,0 +1,77 @@
+package cmd
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/spf13/cobra"
+
+	"github.com/Parapheen/fullkek-starter/internal/scaffold"
+)
+
+func newStatusCommand() *cobra.Command {
+	var opts struct {
+		dir  string
+		diff bool
+		all  bool
+	}
+
+	cmd := &cobra.Command{
+		Use:   "status",
+		Short: "Compare generated files with the project's manifest and current templates.",
+		Long: `Compare generated files with the project's manifest and current templates.
+
+The recorded feature selection is re-rendered in memory and every generated
+file is reported as unchanged, modified, deleted, or newer-template-available.`,
+		Args: cobra.NoArgs,
+		RunE: func(cmd *cobra.Command, _ []string) error {
+			manifest, reports, err := scaffold.DefaultGenerator().Status(context.Background(), opts.dir)
+			if err != nil {
+				return err
+			}
+
+			out := cmd.OutOrStdout()
+			fmt.Fprintf(out, "%s (%s), generated by fullkek %s\n\n", manifest.AppName, manifest.ModulePath, manifest.GeneratorVersion)
+
+			counts := map[scaffold.FileStatus]int{}
+			for _, report := range reports {
+				counts[report.Status]++
+				if report.Status == scaffold.StatusUnchanged && !opts.all {
+					continue
+				}
+
+				owner := report.Feature
+				if owner == "" {
+					owner = "base"
+				}
+				fmt.Fprintf(out, "%-26s %s (%s)\n", report.Status, report.Path, owner)
+
+				if !opts.diff || report.Status == scaffold.StatusDeleted || report.Status == scaffold.StatusUnchanged {
+					continue
+				}
+				if report.PostProcessed {
+					fmt.Fprintf(out, "  (rewritten after generation, e.g. by go mod tidy; no template diff)\n")
+					continue
+				}
+				diff := scaffold.UnifiedDiff("a/"+report.Path+" (project)", "b/"+report.Path+" (template)", report.Disk, report.Rendered)
+				if diff != "" {
+					fmt.Fprintln(out, diff)
+				}
+			}
+
+			fmt.Fprintf(out, "\n%d unchanged, %d modified, %d deleted, %d newer-template-available\n",
+				counts[scaffold.StatusUnchanged],
+				counts[scaffold.StatusModified],
+				counts[scaffold.StatusDeleted],
+				counts[scaffold.StatusOutdated],
+			)
+			return nil
+		},
+	}
+
+	cmd.Flags().StringVarP(&opts.dir, "dir", "C", ".", "project directory")
+	cmd.Flags().BoolVar(&opts.diff, "diff", false, "show a diff between each changed file and its current template")
+	cmd.Flags().BoolVar(&opts.all, "all", false, "also list unchanged files")
+
+	return cmd
+}
@@ -0,0 +1,330 @@
+// Package config loads user and project defaults for the fullkek CLI.
+//
+// Settings are plain "key = value" lines. They are read, lowest precedence
+// first, from the user file under the XDG config directory, the project-local
+// .fullkekrc in the working directory, and FULLKEK_* environment variables.
+// Command-line flags override all of them.
+package config
+
+import (
+	"bufio"
+	"errors"
+	"fmt"
+	"os"
+	"path/filepath"
+	"sort"
+	"strings"
+
+	"github.com/Parapheen/fullkek-starter/internal/stacks"
+)
+
+// ProjectFileName is the project-local configuration file looked up in the working directory.
+const ProjectFileName = ".fullkekrc"
+
+// EnvPrefix prefixes the environment variables that override file settings.
+const EnvPrefix = "FULLKEK_"
+
+// Source identifies where an effective setting came from.
+type Source string
+
+const (
+	SourceUser    Source = "user"
+	SourceProject Source = "project"
+	SourceEnv     Source = "env"
+)
+
+// Key describes a supported configuration setting.
+type Key struct {
+	Name        string
+	Description string
+	// Allowed restricts the accepted values when non-empty.
+	Allowed []string
+	// Category restricts the accepted values to feature identifiers of that
+	// stack category when non-empty.
+	Category string
+}
+
+// PresetsKey names the setting that lists preferred features from any
+// category. Category keys and flags override it.
+const PresetsKey = "presets"
+
+var keys = []Key{
+	{Name: "module-prefix", Description: "prefix joined with the app name to derive the Go module path (e.g. github.com/acme)"},
+	{Name: "conflict", Description: "what to do when the destination is not empty", Allowed: []string{"fail", "overwrite"}},
+	{Name: "template-dirs", Description: "comma-separated directories whose files override the embedded templates"},
+	{Name: PresetsKey, Description: "comma-separated preferred feature identifiers from any category"},
+	{Name: "frontend", Description: "default frontend feature identifier", Category: stacks.CategoryFrontend},
+	{Name: "styling", Description: "default styling feature identifier", Category: stacks.CategoryStyling},
+	{Name: "http", Description: "default HTTP framework feature identifier", Category: stacks.CategoryHTTP},
+	{Name: "database", Description: "default database feature identifier", Category: stacks.CategoryDatabase},
+	{Name: "auth", Description: "default comma-separated authentication feature identifiers", Category: stacks.CategoryAuth},
+	{Name: "oauth-providers", Description: "default comma-separated OAuth providers", Category: stacks.CategoryOAuthProviders},
+	{Name: "account", Description: "default comma-separated account feature identifiers", Category: stacks.CategoryAccount},
+	{Name: "email", Description: "default email feature identifier", Category: stacks.CategoryEmail},
+	{Name: "payments", Description: "default payments feature identifier", Category: stacks.CategoryPayments},
+	{Name: "billing", Description: "default recurring billing feature identifier", Category: stacks.CategoryBilling},
+	{Name: "deploy", Description: "default deployment feature identifier", Category: stacks.CategoryDeploy},
+	{Name: "git", Description: "default git integration mode", Allowed: []string{"none", "init", "commit"}},
+	{Name: "git-message", Description: "default initial commit message"},
+	{Name: "git-author", Description: "default initial commit author (\"Name <email>\")"},
+}
+
+// Keys returns the supported settings in display order.
+func Keys() []Key {
+	out := make([]Key, len(keys))
+	copy(out, keys)
+	return out
+}
+
+// LookupKey returns the definition for the named setting.
+func LookupKey(name string) (Key, bool) {
+	for _, key := range keys {
+		if key.Name == name {
+			return key, true
+		}
+	}
+	return Key{}, false
+}
+
+// Setting is a single effective value together with its origin.
+type Setting struct {
+	Value  string
+	Source Source
+}
+
+// Config holds the merged settings.
+type Config struct {
+	settings map[string]Setting
+}
+
+// Get returns the effective value of the named setting.
+func (c Config) Get(name string) (string, bool) {
+	setting, ok := c.settings[name]
+	if !ok {
+		return "", false
+	}
+	return setting.Value, true
+}
+
+// Lookup returns the effective setting including its source.
+func (c Config) Lookup(name string) (Setting, bool) {
+	setting, ok := c.settings[name]
+	return setting, ok
+}
+
+// List returns the effective settings keyed by name.
+func (c Config) List() map[string]Setting {
+	out := make(map[string]Setting, len(c.settings))
+	for name, setting := range c.settings {
+		out[name] = setting
+	}
+	return out
+}
+
+// UserPath returns the location of the user-level configuration file.
+func UserPath() (string, error) {
+	dir, err := os.UserConfigDir()
+	if err != nil {
+		return "", fmt.Errorf("locate user config directory: %w", err)
+	}
+	return filepath.Join(dir, "fullkek", "config"), nil
+}
+
+// ProjectPath returns the location of the project-local file for dir.
+func ProjectPath(dir string) string {
+	return filepath.Join(dir, ProjectFileName)
+}
+
+// Load merges the user file, the project file found in projectDir, and the
+// environment. Missing files are skipped.
+func Load(projectDir string) (Config, error) {
+	cfg := Config{settings: map[string]Setting{}}
+
+	if userPath, err := UserPath(); err == nil {
+		values, err := ReadFile(userPath)
+		if err != nil {
+			return Config{}, err
+		}
+		cfg.merge(values, SourceUser)
+	}
+
+	values, err := ReadFile(ProjectPath(projectDir))
+	if err != nil {
+		return Config{}, err
+	}
+	cfg.merge(values, SourceProject)
+
+	cfg.merge(fromEnv(os.LookupEnv), SourceEnv)
+
+	return cfg, nil
+}
+
+func (c Config) merge(values map[string]string, source Source) {
+	for name, value := range values {
+		c.settings[name] = Setting{Value: value, Source: source}
+	}
+}
+
+func fromEnv(lookup func(string) (string, bool)) map[string]string {
+	values := map[string]string{}
+	for _, key := range keys {
+		if value, ok := lookup(EnvName(key.Name)); ok && strings.TrimSpace(value) != "" {
+			values[key.Name] = strings.TrimSpace(value)
+		}
+	}
+	return values
+}
+
+// EnvName returns the environment variable overriding the named setting.
+func EnvName(name string) string {
+	return EnvPrefix + strings.ToUpper(strings.ReplaceAll(name, "-", "_"))
+}
+
+// Validate checks that name is a supported setting and value is acceptable for it.
+func Validate(name, value string) error {
+	key, ok := LookupKey(name)
+	if !ok {
+		return fmt.Errorf("unknown config key %q", name)
+	}
+	if key.Category != "" || key.Name == PresetsKey {
+		return validateFeatures(key, value)
+	}
+	if len(key.Allowed) == 0 {
+		return nil
+	}
+	for _, allowed := range key.Allowed {
+		if value == allowed {
+			return nil
+		}
+	}
+	return fmt.Errorf("invalid value %q for %s; valid values: %s", value, name, strings.Join(key.Allowed, ", "))
+}
+
+// FeatureIDs splits a comma-separated feature setting into identifiers. OAuth
+// providers may be written without their "oauth-" prefix, as on the command line.
+func FeatureIDs(name, value string) []string {
+	var ids []string
+	for _, id := range strings.Split(value, ",") {
+		id = strings.TrimSpace(id)
+		if id == "" {
+			continue
+		}
+		if name == stacks.CategoryOAuthProviders && !strings.HasPrefix(id, "oauth-") {
+			id = "oauth-" + id
+		}
+		ids = append(ids, id)
+	}
+	return ids
+}
+
+// validateFeatures checks that value names known features of the key's
+// category, or of any category for presets, and picks at most one feature of
+// each single-choice category.
+func validateFeatures(key Key, value string) error {
+	multiple := map[string]bool{}
+	for _, category := range stacks.Categories() {
+		multiple[category.ID] = category.AllowMultiple
+	}
+
+	picked := map[string]string{}
+	for _, id := range FeatureIDs(key.Name, value) {
+		feature, ok := stacks.FeatureByID(id)
+		if !ok {
+			return fmt.Errorf("invalid value %q for %s; unknown feature %q", value, key.Name, id)
+		}
+		if key.Category != "" && feature.CategoryID != key.Category {
+			return fmt.Errorf("invalid value %q for %s; %s is a %s feature", value, key.Name, id, feature.CategoryID)
+		}
+		if other, ok := picked[feature.CategoryID]; ok && !multiple[feature.CategoryID] {
+			return fmt.Errorf("invalid value %q for %s; %s and %s both pick the %s feature", value, key.Name, other, id, feature.CategoryID)
+		}
+		picked[feature.CategoryID] = id
+	}
+	return nil
+}
+
+// ReadFile parses a configuration file. A missing file yields no settings.
+func ReadFile(path string) (map[string]string, error) {
+	file, err := os.Open(path)
+	if err != nil {
+		if errors.Is(err, os.ErrNotExist) {
+			return map[string]string{}, nil
+		}
+		return nil, fmt.Errorf("open config %s: %w", path, err)
+	}
+	defer file.Close()
+
+	values := map[string]string{}
+	scanner := bufio.NewScanner(file)
+	lineNo := 0
+	for scanner.Scan() {
+		lineNo++
+		line := strings.TrimSpace(scanner.Text())
+		if line == "" || strings.HasPrefix(line, "#") {
+			continue
+		}
+		name, value, ok := strings.Cut(line, "=")
+		if !ok {
+			return nil, fmt.Errorf("%s:%d: expected key = value", path, lineNo)
+		}
+		name = strings.TrimSpace(name)
+		value = strings.TrimSpace(value)
+		if err := Validate(name, value); err != nil {
+			return nil, fmt.Errorf("%s:%d: %w", path, lineNo, err)
+		}
+		values[name] = value
+	}
+	if err := scanner.Err(); err != nil {
+		return nil, fmt.Errorf("read config %s: %w", path, err)
+	}
+
+	return values, nil
+}
+
+// Set stores a single setting in the file at path, creating it when needed.
+// An empty value removes the setting.
+func Set(path, name, value string) error {
+	value = strings.TrimSpace(value)
+	if value != "" {
+		if err := Validate(name, value); err != nil {
+			return err
+		}
+	} else if _, ok := LookupKey(name); !ok {
+		return fmt.Errorf("unknown config key %q", name)
+	}
+
+	values, err := ReadFile(path)
+	if err != nil {
+		return err
+	}
+	if value == "" {
+		delete(values, name)
+	} else {
+		values[name] = value
+	}
+
+	return writeFile(path, values)
+}
+
+func writeFile(path string, values map[string]string) error {
+	if err := os.MkdirAll(filepath.Dir(path), 0o755); err != nil {
+		return fmt.Errorf("create config directory: %w", err)
+	}
+
+	names := make([]string, 0, len(values))
+	for name := range values {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+
+	var b strings.Builder
+	b.WriteString("# fullkek configuration\n")
+	for _, name := range names {
+		fmt.Fprintf(&b, "%s = %s\n", name, values[name])
+	}
+
+	if err := os.WriteFile(path, []byte(b.String()), 0o644); err != nil {
+		return fmt.Errorf("write config %s: %w", path, err)
+	}
+	return nil
+}
@@ -0,0 +1,130 @@
+package config
+
+import (
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+)
+
+func TestLoadAppliesPrecedence(t *testing.T) {
+	userDir := t.TempDir()
+	projectDir := t.TempDir()
+	t.Setenv("XDG_CONFIG_HOME", userDir)
+	t.Setenv("HOME", userDir)
+	t.Setenv(EnvName("http"), "")
+	t.Setenv(EnvName("deploy"), "deploy-ansible")
+
+	userPath, err := UserPath()
+	if err != nil {
+		t.Fatalf("user path: %v", err)
+	}
+	if err := Set(userPath, "styling", "styling-daisyui"); err != nil {
+		t.Fatalf("set user styling: %v", err)
+	}
+	if err := Set(userPath, "http", "http-chi"); err != nil {
+		t.Fatalf("set user http: %v", err)
+	}
+	if err := Set(userPath, "deploy", "deploy-none"); err != nil {
+		t.Fatalf("set user deploy: %v", err)
+	}
+	if err := Set(ProjectPath(projectDir), "styling", "styling-tailwind"); err != nil {
+		t.Fatalf("set project styling: %v", err)
+	}
+
+	cfg, err := Load(projectDir)
+	if err != nil {
+		t.Fatalf("load: %v", err)
+	}
+
+	cases := map[string]Setting{
+		"styling": {Value: "styling-tailwind", Source: SourceProject},
+		"http":    {Value: "http-chi", Source: SourceUser},
+		"deploy":  {Value: "deploy-ansible", Source: SourceEnv},
+	}
+	for name, want := range cases {
+		got, ok := cfg.Lookup(name)
+		if !ok || got != want {
+			t.Fatalf("%s: expected %+v, got %+v (ok=%v)", name, want, got, ok)
+		}
+	}
+}
+
+func TestSetRejectsUnknownKeyAndInvalidValue(t *testing.T) {
+	t.Parallel()
+
+	path := filepath.Join(t.TempDir(), "config")
+
+	if err := Set(path, "colour", "blue"); err == nil || !strings.Contains(err.Error(), "unknown config key") {
+		t.Fatalf("expected unknown key error, got: %v", err)
+	}
+	if err := Set(path, "git", "clone"); err == nil || !strings.Contains(err.Error(), "valid values") {
+		t.Fatalf("expected invalid value error, got: %v", err)
+	}
+}
+
+func TestSetWithEmptyValueRemovesSetting(t *testing.T) {
+	t.Parallel()
+
+	path := filepath.Join(t.TempDir(), "config")
+	if err := Set(path, "module-prefix", "github.com/acme"); err != nil {
+		t.Fatalf("set: %v", err)
+	}
+	if err := Set(path, "module-prefix", ""); err != nil {
+		t.Fatalf("unset: %v", err)
+	}
+
+	values, err := ReadFile(path)
+	if err != nil {
+		t.Fatalf("read: %v", err)
+	}
+	if _, ok := values["module-prefix"]; ok {
+		t.Fatal("expected module-prefix to be removed")
+	}
+}
+
+func TestReadFileReportsLineOfMalformedEntry(t *testing.T) {
+	t.Parallel()
+
+	path := filepath.Join(t.TempDir(), ProjectFileName)
+	if err := os.WriteFile(path, []byte("# defaults\nstyling = styling-tailwind\nhttp-chi\n"), 0o644); err != nil {
+		t.Fatalf("write: %v", err)
+	}
+
+	_, err := ReadFile(path)
+	if err == nil || !strings.Contains(err.Error(), ":3:") {
+		t.Fatalf("expected error on line 3, got: %v", err)
+	}
+}
+
+func TestSetValidatesFeatureIdentifiers(t *testing.T) {
+	t.Parallel()
+
+	path := filepath.Join(t.TempDir(), "config")
+
+	cases := map[string][2]string{
+		"unknown feature":      {"styling", "styling-bootstrap"},
+		"wrong category":       {"styling", "http-chi"},
+		"single-choice twice":  {"http", "http-chi,http-standard"},
+		"unknown preset":       {PresetsKey, "http-chi,deploy-k8s"},
+		"preset picks two":     {PresetsKey, "styling-daisyui,styling-tailwind"},
+		"provider of wrong id": {"oauth-providers", "github,account-roles"},
+	}
+	for name, c := range cases {
+		if err := Set(path, c[0], c[1]); err == nil || !strings.Contains(err.Error(), "invalid value") {
+			t.Fatalf("%s: expected invalid value error, got: %v", name, err)
+		}
+	}
+
+	valid := map[string]string{
+		"styling":         "styling-daisyui",
+		"account":         "account-2fa,account-roles",
+		"oauth-providers": "github,oauth-google",
+		PresetsKey:        "http-chi,styling-daisyui,account-roles",
+	}
+	for name, value := range valid {
+		if err := Set(path, name, value); err != nil {
+			t.Fatalf("set %s=%s: %v", name, value, err)
+		}
+	}
+}
@@ -0,0 +1,145 @@
+package scaffold
+
+import (
+	"fmt"
+	"strings"
+)
+
+// diffContext is the number of unchanged lines shown around each change.
+const diffContext = 3
+
+type diffOp struct {
+	kind byte // ' ', '-', '+'
+	line string
+}
+
+// UnifiedDiff renders a unified diff turning a into b. It returns an empty
+// string when both are identical.
+func UnifiedDiff(aName, bName string, a, b []byte) string {
+	if string(a) == string(b) {
+		return ""
+	}
+
+	ops := diffLines(splitLines(string(a)), splitLines(string(b)))
+
+	var out strings.Builder
+	fmt.Fprintf(&out, "--- %s\n+++ %s\n", aName, bName)
+
+	for start := 0; start < len(ops); {
+		// Find the next change.
+		first := start
+		for first < len(ops) && ops[first].kind == ' ' {
+			first++
+		}
+		if first == len(ops) {
+			break
+		}
+
+		// Extend the hunk while changes are within two contexts of each other.
+		hunkStart := max(first-diffContext, start)
+		end := first
+		for {
+			for end < len(ops) && ops[end].kind != ' ' {
+				end++
+			}
+			next := end
+			for next < len(ops) && ops[next].kind == ' ' {
+				next++
+			}
+			if next == len(ops) || next-end > 2*diffContext {
+				break
+			}
+			end = next
+		}
+		hunkEnd := min(end+diffContext, len(ops))
+
+		aLine, bLine := 1, 1
+		for _, op := range ops[:hunkStart] {
+			if op.kind != '+' {
+				aLine++
+			}
+			if op.kind != '-' {
+				bLine++
+			}
+		}
+		aCount, bCount := 0, 0
+		for _, op := range ops[hunkStart:hunkEnd] {
+			if op.kind != '+' {
+				aCount++
+			}
+			if op.kind != '-' {
+				bCount++
+			}
+		}
+
+		fmt.Fprintf(&out, "@@ -%s +%s @@\n", hunkRange(aLine, aCount), hunkRange(bLine, bCount))
+		for _, op := range ops[hunkStart:hunkEnd] {
+			out.WriteByte(op.kind)
+			out.WriteString(op.line)
+			out.WriteByte('\n')
+		}
+
+		start = hunkEnd
+	}
+
+	return out.String()
+}
+
+func hunkRange(line, count int) string {
+	if count == 0 {
+		return fmt.Sprintf("%d,0", line-1)
+	}
+	if count == 1 {
+		return fmt.Sprintf("%d", line)
+	}
+	return fmt.Sprintf("%d,%d", line, count)
+}
+
+func splitLines(s string) []string {
+	if s == "" {
+		return nil
+	}
+	return strings.Split(strings.TrimSuffix(s, "\n"), "\n")
+}
+
+// diffLines computes a line diff from the longest common subsequence of a and b.
+func diffLines(a, b []string) []diffOp {
+	lcs := make([][]int, len(a)+1)
+	for i := range lcs {
+		lcs[i] = make([]int, len(b)+1)
+	}
+	for i := len(a) - 1; i >= 0; i-- {
+		for j := len(b) - 1; j >= 0; j-- {
+			if a[i] == b[j] {
+				lcs[i][j] = lcs[i+1][j+1] + 1
+			} else {
+				lcs[i][j] = max(lcs[i+1][j], lcs[i][j+1])
+			}
+		}
+	}
+
+	ops := make([]diffOp, 0, len(a)+len(b))
+	i, j := 0, 0
+	for i < len(a) && j < len(b) {
+		switch {
+		case a[i] == b[j]:
+			ops = append(ops, diffOp{kind: ' ', line: a[i]})
+			i++
+			j++
+		case lcs[i+1][j] >= lcs[i][j+1]:
+			ops = append(ops, diffOp{kind: '-', line: a[i]})
+			i++
+		default:
+			ops = append(ops, diffOp{kind: '+', line: b[j]})
+			j++
+		}
+	}
+	for ; i < len(a); i++ {
+		ops = append(ops, diffOp{kind: '-', line: a[i]})
+	}
+	for ; j < len(b); j++ {
+		ops = append(ops, diffOp{kind: '+', line: b[j]})
+	}
+
+	return ops
+}
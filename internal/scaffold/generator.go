@@ -1,9 +1,11 @@
 package scaffold
 
 import (
+	"bytes"
 	"context"
 	"errors"
 	"fmt"
+	"io"
 	"io/fs"
 	"os"
 	"os/exec"
@@ -23,6 +25,10 @@ type Options struct {
 	Destination string
 	Stack       stacks.Stack
 	Force       bool
+	Git         GitOptions
+	// Warnings receives non-fatal diagnostics such as a missing git binary.
+	// A nil writer discards them.
+	Warnings io.Writer
 }
 
 // Generator renders the templates embedded in the CLI.
@@ -47,6 +53,10 @@ func (g *Generator) Generate(ctx context.Context, opts Options) error {
 	if opts.ModulePath == "" {
 		return errors.New("module path is required")
 	}
+	// Reject a bad commit author before anything touches disk.
+	if err := ValidateCommitAuthor(opts.Git.CommitAuthor); err != nil {
+		return err
+	}
 
 	root := opts.Destination
 	if root == "" {
@@ -67,57 +77,90 @@ func (g *Generator) Generate(ctx context.Context, opts Options) error {
 		}
 	}
 
-	data := struct {
-		AppName    string
-		ModulePath string
-		Stack      stacks.Stack
-		Generated  time.Time
-	}{
-		AppName:    opts.AppName,
-		ModulePath: opts.ModulePath,
-		Stack:      opts.Stack,
-		Generated:  time.Now().UTC(),
+	files, err := g.Render(ctx, opts.AppName, opts.ModulePath, opts.Stack)
+	if err != nil {
+		return err
 	}
 
-	templatesToRender := append([]stacks.Template{}, BaseTemplates...)
-	templatesToRender = append(templatesToRender, opts.Stack.Templates...)
-
-	for _, tmpl := range templatesToRender {
+	for _, file := range files {
 		if err := ctx.Err(); err != nil {
 			return err
 		}
-		if err := g.renderTemplate(root, tmpl, data); err != nil {
+		if err := writeRenderedFile(root, file); err != nil {
 			return err
 		}
 	}
 
-	if err := initGitRepository(ctx, root); err != nil {
-		return err
-	}
-
 	if err := runGoModTidy(ctx, root); err != nil {
 		// Keep scaffolding successful even when dependency resolution is unavailable
 		// (for example offline or behind a restricted proxy). The generated project
 		// can still be used after running `go mod tidy` in a networked environment.
 	}
 
+	manifest, err := NewManifest(root, opts.AppName, opts.ModulePath, opts.Stack, files)
+	if err != nil {
+		return err
+	}
+	if err := WriteManifest(root, manifest); err != nil {
+		return err
+	}
+
+	warnings := opts.Warnings
+	if warnings == nil {
+		warnings = io.Discard
+	}
+	if err := setupGit(ctx, root, opts.Git, warnings); err != nil {
+		return err
+	}
+
 	return nil
 }
 
-func initGitRepository(ctx context.Context, root string) error {
-	command := exec.CommandContext(ctx, "git", "init")
-	command.Dir = root
+// RenderedFile is a template rendered in memory, ready to be written to disk.
+type RenderedFile struct {
+	Template stacks.Template
+	Content  []byte
+}
 
-	output, err := command.CombinedOutput()
-	if err != nil {
-		trimmed := strings.TrimSpace(string(output))
-		if trimmed == "" {
-			return fmt.Errorf("initialize git repository: %w", err)
+// Render executes every base and stack template in memory. When several
+// templates target the same destination the stack template wins, matching the
+// order files are written in.
+func (g *Generator) Render(ctx context.Context, appName, modulePath string, stack stacks.Stack) ([]RenderedFile, error) {
+	data := struct {
+		AppName    string
+		ModulePath string
+		Stack      stacks.Stack
+		Generated  time.Time
+	}{
+		AppName:    appName,
+		ModulePath: modulePath,
+		Stack:      stack,
+		Generated:  time.Now().UTC(),
+	}
+
+	templatesToRender := append([]stacks.Template{}, BaseTemplates...)
+	templatesToRender = append(templatesToRender, stack.Templates...)
+
+	files := make([]RenderedFile, 0, len(templatesToRender))
+	index := make(map[string]int, len(templatesToRender))
+	for _, tmpl := range templatesToRender {
+		if err := ctx.Err(); err != nil {
+			return nil, err
+		}
+		content, err := g.renderTemplate(tmpl, data)
+		if err != nil {
+			return nil, err
 		}
-		return fmt.Errorf("initialize git repository: %w: %s", err, trimmed)
+		file := RenderedFile{Template: tmpl, Content: content}
+		if i, ok := index[tmpl.Destination]; ok {
+			files[i] = file
+			continue
+		}
+		index[tmpl.Destination] = len(files)
+		files = append(files, file)
 	}
 
-	return nil
+	return files, nil
 }
 
 func runGoModTidy(ctx context.Context, root string) error {
@@ -136,10 +179,13 @@ func runGoModTidy(ctx context.Context, root string) error {
 	return nil
 }
 
-func (g *Generator) renderTemplate(root string, tmpl stacks.Template, data any) error {
-	target := filepath.Join(root, tmpl.Destination)
-	if err := os.MkdirAll(filepath.Dir(target), 0o755); err != nil {
-		return fmt.Errorf("prepare directory for %s: %w", tmpl.Destination, err)
+func (g *Generator) renderTemplate(tmpl stacks.Template, data any) ([]byte, error) {
+	if tmpl.Verbatim {
+		content, err := fs.ReadFile(g.fs, tmpl.Source)
+		if err != nil {
+			return nil, fmt.Errorf("read template %s: %w", tmpl.Source, err)
+		}
+		return content, nil
 	}
 
 	funcMap := template.FuncMap{
@@ -153,19 +199,31 @@ func (g *Generator) renderTemplate(root string, tmpl stacks.Template, data any)
 		},
 	}
 
-	parsed, err := template.New(filepath.Base(tmpl.Source)).Funcs(funcMap).Option("missingkey=error").ParseFS(g.fs, tmpl.Source)
+	parsed, err := template.New(filepath.Base(tmpl.Source)).
+		Delims(tmpl.Delims.Left, tmpl.Delims.Right).
+		Funcs(funcMap).
+		Option("missingkey=error").
+		ParseFS(g.fs, tmpl.Source)
 	if err != nil {
-		return fmt.Errorf("parse template %s: %w", tmpl.Source, err)
+		return nil, fmt.Errorf("parse template %s: %w", tmpl.Source, err)
 	}
 
-	file, err := os.OpenFile(target, os.O_CREATE|os.O_WRONLY|os.O_TRUNC, fileModeOrDefault(tmpl.Mode))
-	if err != nil {
-		return fmt.Errorf("open target %s: %w", tmpl.Destination, err)
+	var buf bytes.Buffer
+	if err := parsed.Execute(&buf, data); err != nil {
+		return nil, fmt.Errorf("execute template %s: %w", tmpl.Source, err)
+	}
+
+	return buf.Bytes(), nil
+}
+
+func writeRenderedFile(root string, file RenderedFile) error {
+	target := filepath.Join(root, file.Template.Destination)
+	if err := os.MkdirAll(filepath.Dir(target), 0o755); err != nil {
+		return fmt.Errorf("prepare directory for %s: %w", file.Template.Destination, err)
 	}
-	defer file.Close()
 
-	if err := parsed.Execute(file, data); err != nil {
-		return fmt.Errorf("execute template %s: %w", tmpl.Source, err)
+	if err := os.WriteFile(target, file.Content, fileModeOrDefault(file.Template.Mode)); err != nil {
+		return fmt.Errorf("write target %s: %w", file.Template.Destination, err)
 	}
 
 	return nil
@@ -1,11 +1,14 @@
 package scaffold
 
 import (
+	"bytes"
 	"context"
 	"os"
 	"os/exec"
 	"path/filepath"
+	"strings"
 	"testing"
+	"testing/fstest"
 
 	"github.com/Parapheen/fullkek-starter/internal/stacks"
 )
@@ -43,3 +46,278 @@ func TestGenerateInitializesGitRepository(t *testing.T) {
 		t.Fatal("expected .git to be a directory")
 	}
 }
+
+func TestGenerateWithGitNoneSkipsRepository(t *testing.T) {
+	t.Parallel()
+
+	stack, err := stacks.Compose(stacks.DefaultSelection())
+	if err != nil {
+		t.Fatalf("compose stack: %v", err)
+	}
+
+	destination := filepath.Join(t.TempDir(), "my-app")
+	err = DefaultGenerator().Generate(context.Background(), Options{
+		AppName:     "my-app",
+		ModulePath:  "example.com/my-app",
+		Destination: destination,
+		Stack:       stack,
+		Git:         GitOptions{Mode: GitNone},
+	})
+	if err != nil {
+		t.Fatalf("generate project: %v", err)
+	}
+
+	if _, err := os.Stat(filepath.Join(destination, ".git")); !os.IsNotExist(err) {
+		t.Fatalf("expected no .git directory, got: %v", err)
+	}
+}
+
+func TestGenerateWithGitCommitCreatesInitialCommit(t *testing.T) {
+	t.Parallel()
+
+	if _, err := exec.LookPath("git"); err != nil {
+		t.Skip("git is not available in PATH")
+	}
+
+	stack, err := stacks.Compose(stacks.DefaultSelection())
+	if err != nil {
+		t.Fatalf("compose stack: %v", err)
+	}
+
+	destination := filepath.Join(t.TempDir(), "my-app")
+	err = DefaultGenerator().Generate(context.Background(), Options{
+		AppName:     "my-app",
+		ModulePath:  "example.com/my-app",
+		Destination: destination,
+		Stack:       stack,
+		Git: GitOptions{
+			Mode:          GitCommit,
+			CommitMessage: "chore: scaffold",
+			CommitAuthor:  "Fullkek Bot <bot@example.com>",
+		},
+	})
+	if err != nil {
+		t.Fatalf("generate project: %v", err)
+	}
+
+	output, err := exec.Command("git", "-C", destination, "log", "-1", "--format=%an <%ae>|%s").CombinedOutput()
+	if err != nil {
+		t.Fatalf("git log: %v: %s", err, output)
+	}
+	if got := strings.TrimSpace(string(output)); got != "Fullkek Bot <bot@example.com>|chore: scaffold" {
+		t.Fatalf("unexpected initial commit: %q", got)
+	}
+}
+
+func TestGenerateInsideExistingRepositorySkipsNestedGit(t *testing.T) {
+	t.Parallel()
+
+	if _, err := exec.LookPath("git"); err != nil {
+		t.Skip("git is not available in PATH")
+	}
+
+	monorepo := t.TempDir()
+	if output, err := exec.Command("git", "-C", monorepo, "init").CombinedOutput(); err != nil {
+		t.Fatalf("git init: %v: %s", err, output)
+	}
+
+	stack, err := stacks.Compose(stacks.DefaultSelection())
+	if err != nil {
+		t.Fatalf("compose stack: %v", err)
+	}
+
+	var warnings bytes.Buffer
+	destination := filepath.Join(monorepo, "services", "my-app")
+	err = DefaultGenerator().Generate(context.Background(), Options{
+		AppName:     "my-app",
+		ModulePath:  "example.com/my-app",
+		Destination: destination,
+		Stack:       stack,
+		Warnings:    &warnings,
+	})
+	if err != nil {
+		t.Fatalf("generate project: %v", err)
+	}
+
+	if _, err := os.Stat(filepath.Join(destination, ".git")); !os.IsNotExist(err) {
+		t.Fatalf("expected no nested .git directory, got: %v", err)
+	}
+	if !strings.Contains(warnings.String(), "inside the git repository") {
+		t.Fatalf("expected enclosing repository note, got: %q", warnings.String())
+	}
+}
+
+func TestParseGitModeRejectsUnknownValue(t *testing.T) {
+	t.Parallel()
+
+	if _, err := ParseGitMode("clone"); err == nil {
+		t.Fatal("expected error for unknown git mode")
+	}
+
+	mode, err := ParseGitMode("")
+	if err != nil || mode != GitInit {
+		t.Fatalf("expected empty value to select init, got %q (%v)", mode, err)
+	}
+}
+
+func TestGenerateWritesManifest(t *testing.T) {
+	t.Parallel()
+
+	stack, err := stacks.Compose(stacks.DefaultSelection())
+	if err != nil {
+		t.Fatalf("compose stack: %v", err)
+	}
+
+	destination := filepath.Join(t.TempDir(), "my-app")
+	err = DefaultGenerator().Generate(context.Background(), Options{
+		AppName:     "my-app",
+		ModulePath:  "example.com/my-app",
+		Destination: destination,
+		Stack:       stack,
+		Git:         GitOptions{Mode: GitNone},
+	})
+	if err != nil {
+		t.Fatalf("generate project: %v", err)
+	}
+
+	manifest, err := ReadManifest(destination)
+	if err != nil {
+		t.Fatalf("read manifest: %v", err)
+	}
+	if manifest.ModulePath != "example.com/my-app" {
+		t.Fatalf("unexpected module path %q", manifest.ModulePath)
+	}
+
+	router, ok := manifest.File("internal/transport/http/router.go")
+	if !ok {
+		t.Fatal("expected router.go to be tracked")
+	}
+	if router.Feature != "http-standard" {
+		t.Fatalf("expected router.go to be owned by http-standard, got %q", router.Feature)
+	}
+	if _, ok := manifest.File(ManifestFileName); ok {
+		t.Fatal("manifest should not track itself")
+	}
+}
+
+func TestRenderDeployStackTrustsLoopbackProxy(t *testing.T) {
+	t.Parallel()
+
+	selection := stacks.DefaultSelection()
+	selection[stacks.CategoryDatabase] = []string{"database-sqlite"}
+	selection[stacks.CategoryPayments] = []string{"payments-yookassa"}
+	selection[stacks.CategoryDeploy] = []string{"deploy-ansible"}
+	stack, err := stacks.Compose(selection)
+	if err != nil {
+		t.Fatalf("compose stack: %v", err)
+	}
+
+	files, err := DefaultGenerator().Render(context.Background(), "my-app", "example.com/my-app", stack)
+	if err != nil {
+		t.Fatalf("render stack: %v", err)
+	}
+
+	rendered := make(map[string]string, len(files))
+	for _, file := range files {
+		rendered[file.Template.Destination] = string(file.Content)
+	}
+
+	if !strings.Contains(rendered[".env"], "TRUSTED_PROXIES=127.0.0.1/32,::1/128") {
+		t.Fatal("expected .env to trust the loopback Caddy proxy")
+	}
+	if !strings.Contains(rendered["deploy/templates/Caddyfile.j2"], "header_up X-Forwarded-For {remote_host}") {
+		t.Fatal("expected Caddyfile to set X-Forwarded-For")
+	}
+	if _, ok := rendered["internal/transport/http/client_ip.go"]; !ok {
+		t.Fatal("expected client_ip.go to be generated")
+	}
+	if !strings.Contains(rendered["internal/transport/http/payment_handlers.go"], "RemoteIP: ClientIP(req)") {
+		t.Fatal("expected webhook allowlist to use the resolved client IP")
+	}
+}
+
+func TestRenderTemplateHonoursDelimsAndVerbatim(t *testing.T) {
+	t.Parallel()
+
+	generator := NewGenerator(fstest.MapFS{
+		"page.html.tmpl": {Data: []byte(`{{ define "title" }}[[ .AppName ]]{{ end }}[[ if .Stack.HasFeature "styling-daisyui" ]] daisy[[ end ]]`)},
+		"app.j2.tmpl":    {Data: []byte(`User={{ "{{ app_user }}" }}`)},
+	})
+	data := struct {
+		AppName string
+		Stack   stacks.Stack
+	}{AppName: "my-app"}
+
+	page, err := generator.renderTemplate(stacks.Template{Source: "page.html.tmpl", Delims: stacks.BracketDelims}, data)
+	if err != nil {
+		t.Fatalf("render page: %v", err)
+	}
+	if got := string(page); got != `{{ define "title" }}my-app{{ end }}` {
+		t.Fatalf("expected [[ ]] actions to render and {{ }} to stay, got %q", got)
+	}
+
+	unit, err := generator.renderTemplate(stacks.Template{Source: "app.j2.tmpl", Verbatim: true}, data)
+	if err != nil {
+		t.Fatalf("render unit: %v", err)
+	}
+	if got := string(unit); got != `User={{ "{{ app_user }}" }}` {
+		t.Fatalf("expected the verbatim template to be copied as is, got %q", got)
+	}
+}
+
+func TestGenerateWithGitCommitLeavesCleanWorkingTree(t *testing.T) {
+	t.Parallel()
+
+	if _, err := exec.LookPath("git"); err != nil {
+		t.Skip("git is not available in PATH")
+	}
+
+	stack, err := stacks.Compose(stacks.DefaultSelection())
+	if err != nil {
+		t.Fatalf("compose stack: %v", err)
+	}
+
+	destination := filepath.Join(t.TempDir(), "my-app")
+	err = DefaultGenerator().Generate(context.Background(), Options{
+		AppName:     "my-app",
+		ModulePath:  "example.com/my-app",
+		Destination: destination,
+		Stack:       stack,
+		Git:         GitOptions{Mode: GitCommit, CommitAuthor: "Fullkek Bot <bot@example.com>"},
+	})
+	if err != nil {
+		t.Fatalf("generate project: %v", err)
+	}
+
+	output, err := exec.Command("git", "-C", destination, "status", "--porcelain").CombinedOutput()
+	if err != nil {
+		t.Fatalf("git status: %v: %s", err, output)
+	}
+	if got := strings.TrimSpace(string(output)); got != "" {
+		t.Fatalf("expected a clean working tree after the initial commit, got:\n%s", got)
+	}
+}
+
+func TestGenerateRejectsInvalidCommitAuthorBeforeWriting(t *testing.T) {
+	t.Parallel()
+
+	stack, err := stacks.Compose(stacks.DefaultSelection())
+	if err != nil {
+		t.Fatalf("compose stack: %v", err)
+	}
+
+	destination := filepath.Join(t.TempDir(), "my-app")
+	err = DefaultGenerator().Generate(context.Background(), Options{
+		AppName:     "my-app",
+		ModulePath:  "example.com/my-app",
+		Destination: destination,
+		Stack:       stack,
+		Git:         GitOptions{Mode: GitCommit, CommitAuthor: "no-email"},
+	})
+	if err == nil || !strings.Contains(err.Error(), "invalid commit author") {
+		t.Fatalf("expected an invalid author error, got: %v", err)
+	}
+	if _, err := os.Stat(destination); !os.IsNotExist(err) {
+		t.Fatalf("expected nothing to be written, got: %v", err)
+	}
+}
@@ -0,0 +1,179 @@
+package scaffold
+
+import (
+	"context"
+	"fmt"
+	"io"
+	"os"
+	"os/exec"
+	"path/filepath"
+	"strings"
+)
+
+// GitMode controls how the generator integrates with git.
+type GitMode string
+
+const (
+	// GitNone leaves the generated project without any git metadata.
+	GitNone GitMode = "none"
+	// GitInit initializes an empty repository in the generated project.
+	GitInit GitMode = "init"
+	// GitCommit initializes a repository and records every generated file in an initial commit.
+	GitCommit GitMode = "commit"
+)
+
+// DefaultCommitMessage is used for the initial commit when none is configured.
+const DefaultCommitMessage = "Initial commit from fullkek"
+
+// GitModes lists the supported git integration modes.
+func GitModes() []GitMode {
+	return []GitMode{GitNone, GitInit, GitCommit}
+}
+
+// ParseGitMode converts user input into a GitMode. An empty value selects GitInit.
+func ParseGitMode(value string) (GitMode, error) {
+	switch mode := GitMode(strings.ToLower(strings.TrimSpace(value))); mode {
+	case "":
+		return GitInit, nil
+	case GitNone, GitInit, GitCommit:
+		return mode, nil
+	default:
+		return "", fmt.Errorf("unknown git mode %q; valid values: none, init, commit", value)
+	}
+}
+
+// GitOptions configures the repository created for the generated project.
+type GitOptions struct {
+	// Mode selects whether to skip git, initialize a repository, or also commit.
+	Mode GitMode
+	// CommitMessage overrides DefaultCommitMessage for GitCommit.
+	CommitMessage string
+	// CommitAuthor overrides the author of the initial commit ("Name <email>").
+	CommitAuthor string
+}
+
+func setupGit(ctx context.Context, root string, opts GitOptions, warn io.Writer) error {
+	mode := opts.Mode
+	if mode == "" {
+		mode = GitInit
+	}
+	if mode == GitNone {
+		return nil
+	}
+
+	if _, err := exec.LookPath("git"); err != nil {
+		fmt.Fprintln(warn, "warning: git not found in PATH; skipping repository initialization")
+		return nil
+	}
+
+	if toplevel, ok := enclosingRepository(ctx, root); ok {
+		fmt.Fprintf(warn, "note: %s is inside the git repository %s; skipping git %s\n", root, toplevel, mode)
+		return nil
+	}
+
+	if _, err := runGit(ctx, root, nil, "init"); err != nil {
+		return fmt.Errorf("initialize git repository: %w", err)
+	}
+
+	if mode != GitCommit {
+		return nil
+	}
+
+	env, err := commitAuthorEnv(opts.CommitAuthor)
+	if err != nil {
+		return err
+	}
+
+	message := strings.TrimSpace(opts.CommitMessage)
+	if message == "" {
+		message = DefaultCommitMessage
+	}
+
+	if _, err := runGit(ctx, root, nil, "add", "-A"); err != nil {
+		return fmt.Errorf("stage generated files: %w", err)
+	}
+	if _, err := runGit(ctx, root, env, "commit", "--no-verify", "-m", message); err != nil {
+		return fmt.Errorf("create initial commit: %w", err)
+	}
+
+	return nil
+}
+
+// enclosingRepository reports the top-level directory of a repository that
+// already contains root, so generation inside a monorepo does not nest .git.
+func enclosingRepository(ctx context.Context, root string) (string, bool) {
+	abs, err := filepath.Abs(root)
+	if err != nil {
+		return "", false
+	}
+
+	output, err := runGit(ctx, filepath.Dir(abs), nil, "rev-parse", "--show-toplevel")
+	if err != nil {
+		return "", false
+	}
+
+	toplevel := strings.TrimSpace(output)
+	if toplevel == "" {
+		return "", false
+	}
+	return toplevel, true
+}
+
+// ValidateCommitAuthor checks a --git-author value ("Name <email>"). An empty
+// value is valid and keeps git's configured identity.
+func ValidateCommitAuthor(author string) error {
+	_, _, err := parseCommitAuthor(author)
+	return err
+}
+
+func parseCommitAuthor(author string) (string, string, error) {
+	author = strings.TrimSpace(author)
+	if author == "" {
+		return "", "", nil
+	}
+
+	open := strings.LastIndex(author, "<")
+	if open < 0 || !strings.HasSuffix(author, ">") {
+		return "", "", fmt.Errorf("invalid commit author %q; expected \"Name <email>\"", author)
+	}
+
+	name := strings.TrimSpace(author[:open])
+	email := strings.TrimSpace(author[open+1 : len(author)-1])
+	if name == "" || email == "" {
+		return "", "", fmt.Errorf("invalid commit author %q; expected \"Name <email>\"", author)
+	}
+	return name, email, nil
+}
+
+func commitAuthorEnv(author string) ([]string, error) {
+	name, email, err := parseCommitAuthor(author)
+	if err != nil || name == "" {
+		return nil, err
+	}
+
+	return []string{
+		"GIT_AUTHOR_NAME=" + name,
+		"GIT_AUTHOR_EMAIL=" + email,
+		"GIT_COMMITTER_NAME=" + name,
+		"GIT_COMMITTER_EMAIL=" + email,
+	}, nil
+}
+
+func runGit(ctx context.Context, dir string, env []string, args ...string) (string, error) {
+	command := exec.CommandContext(ctx, "git", args...)
+	command.Dir = dir
+	if len(env) > 0 {
+		command.Env = append(os.Environ(), env...)
+	}
+
+	output, err := command.CombinedOutput()
+	if err != nil {
+		trimmed := strings.TrimSpace(string(output))
+		if trimmed == "" {
+			return "", err
+		}
+		return "", fmt.Errorf("%w: %s", err, trimmed)
+	}
+
+	return string(output), nil
+}
@@ -66,6 +66,16 @@ var BaseTemplates = []stacks.Template{
 		Destination: "internal/transport/http/server.go",
 		Mode:        0o644,
 	},
+	{
+		Source:      "base/internal/transport/http/client_ip.go.tmpl",
+		Destination: "internal/transport/http/client_ip.go",
+		Mode:        0o644,
+	},
+	{
+		Source:      "base/internal/transport/http/client_ip_test.go.tmpl",
+		Destination: "internal/transport/http/client_ip_test.go",
+		Mode:        0o644,
+	},
 	{
 		Source:      "base/internal/transport/http/router.go.tmpl",
 		Destination: "internal/transport/http/router.go",
@@ -80,16 +90,19 @@ var BaseTemplates = []stacks.Template{
 		Source:      "base/web/templates/base.html.tmpl",
 		Destination: "web/templates/base.html",
 		Mode:        0o644,
+		Delims:      stacks.BracketDelims,
 	},
 	{
 		Source:      "base/web/templates/navbar.html.tmpl",
 		Destination: "web/templates/navbar.html",
 		Mode:        0o644,
+		Delims:      stacks.BracketDelims,
 	},
 	{
 		Source:      "base/web/templates/footer.html.tmpl",
 		Destination: "web/templates/footer.html",
 		Mode:        0o644,
+		Delims:      stacks.BracketDelims,
 	},
 	{
 		Source:      "base/web/assets/styles/README.md.tmpl",
@@ -0,0 +1,161 @@
+package scaffold
+
+import (
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"os"
+	"path/filepath"
+	"runtime/debug"
+	"sort"
+
+	"github.com/Parapheen/fullkek-starter/internal/stacks"
+)
+
+// ManifestFileName is the generator manifest written at the project root.
+const ManifestFileName = ".fullkek.json"
+
+// manifestVersion is bumped whenever the manifest layout changes incompatibly.
+const manifestVersion = 1
+
+// ErrNoManifest is returned when a directory was not generated by fullkek (or
+// predates the manifest).
+var ErrNoManifest = errors.New("no " + ManifestFileName + " found; was this project generated by fullkek?")
+
+// Manifest records what the generator produced so later commands can tell
+// generated files from hand-written ones.
+type Manifest struct {
+	Version          int              `json:"version"`
+	GeneratorVersion string           `json:"generator_version,omitempty"`
+	AppName          string           `json:"app_name"`
+	ModulePath       string           `json:"module_path"`
+	Selection        stacks.Selection `json:"selection"`
+	Files            []ManifestFile   `json:"files"`
+}
+
+// ManifestFile tracks a single generated file.
+type ManifestFile struct {
+	Path   string `json:"path"`
+	Source string `json:"source"`
+	// Feature owns the file; empty for base templates shared by every stack.
+	Feature string `json:"feature,omitempty"`
+	// Checksum is the SHA-256 of the file on disk when generation finished.
+	Checksum string `json:"checksum"`
+	// TemplateChecksum is the SHA-256 of the rendered template. It differs from
+	// Checksum when post-processing (such as go mod tidy) rewrote the file.
+	TemplateChecksum string `json:"template_checksum"`
+}
+
+// NewManifest describes the rendered files as they now exist under root.
+func NewManifest(root, appName, modulePath string, stack stacks.Stack, files []RenderedFile) (Manifest, error) {
+	manifest := Manifest{
+		Version:          manifestVersion,
+		GeneratorVersion: generatorVersion(),
+		AppName:          appName,
+		ModulePath:       modulePath,
+		Selection:        stack.Selection(),
+		Files:            make([]ManifestFile, 0, len(files)),
+	}
+
+	for _, file := range files {
+		entry, err := manifestEntry(root, file)
+		if err != nil {
+			return Manifest{}, err
+		}
+		manifest.Files = append(manifest.Files, entry)
+	}
+	manifest.sortFiles()
+
+	return manifest, nil
+}
+
+func manifestEntry(root string, file RenderedFile) (ManifestFile, error) {
+	checksum, err := FileChecksum(filepath.Join(root, file.Template.Destination))
+	if err != nil {
+		return ManifestFile{}, err
+	}
+	return ManifestFile{
+		Path:             file.Template.Destination,
+		Source:           file.Template.Source,
+		Feature:          file.Template.Feature,
+		Checksum:         checksum,
+		TemplateChecksum: Checksum(file.Content),
+	}, nil
+}
+
+// File returns the manifest entry for the relative path.
+func (m Manifest) File(path string) (ManifestFile, bool) {
+	for _, file := range m.Files {
+		if file.Path == path {
+			return file, true
+		}
+	}
+	return ManifestFile{}, false
+}
+
+func (m *Manifest) sortFiles() {
+	sort.Slice(m.Files, func(i, j int) bool {
+		return m.Files[i].Path < m.Files[j].Path
+	})
+}
+
+// ReadManifest loads the manifest stored at the root of a generated project.
+func ReadManifest(root string) (Manifest, error) {
+	data, err := os.ReadFile(filepath.Join(root, ManifestFileName))
+	if err != nil {
+		if errors.Is(err, os.ErrNotExist) {
+			return Manifest{}, ErrNoManifest
+		}
+		return Manifest{}, fmt.Errorf("read manifest: %w", err)
+	}
+
+	var manifest Manifest
+	if err := json.Unmarshal(data, &manifest); err != nil {
+		return Manifest{}, fmt.Errorf("parse manifest: %w", err)
+	}
+	if manifest.Version > manifestVersion {
+		return Manifest{}, fmt.Errorf("manifest version %d is newer than this fullkek supports (%d); upgrade the CLI", manifest.Version, manifestVersion)
+	}
+
+	return manifest, nil
+}
+
+// WriteManifest stores the manifest at the root of a generated project.
+func WriteManifest(root string, manifest Manifest) error {
+	manifest.sortFiles()
+	data, err := json.MarshalIndent(manifest, "", "  ")
+	if err != nil {
+		return fmt.Errorf("encode manifest: %w", err)
+	}
+	data = append(data, '\n')
+
+	if err := os.WriteFile(filepath.Join(root, ManifestFileName), data, 0o644); err != nil {
+		return fmt.Errorf("write manifest: %w", err)
+	}
+	return nil
+}
+
+// Checksum returns the hex-encoded SHA-256 of content.
+func Checksum(content []byte) string {
+	sum := sha256.Sum256(content)
+	return hex.EncodeToString(sum[:])
+}
+
+// FileChecksum returns the checksum of the file at path.
+func FileChecksum(path string) (string, error) {
+	content, err := os.ReadFile(path)
+	if err != nil {
+		return "", fmt.Errorf("checksum %s: %w", path, err)
+	}
+	return Checksum(content), nil
+}
+
+func generatorVersion() string {
+	info, ok := debug.ReadBuildInfo()
+	if !ok || info.Main.Version == "" {
+		return "(devel)"
+	}
+	return info.Main.Version
+}
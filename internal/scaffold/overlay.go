@@ -0,0 +1,43 @@
+package scaffold
+
+import (
+	"errors"
+	"io/fs"
+	"os"
+)
+
+// overlayFS resolves each path against its layers in order, so files in
+// user-provided template directories shadow the embedded ones.
+type overlayFS struct {
+	layers []fs.FS
+}
+
+// OverlayFS returns a filesystem that serves files from dirs before falling
+// back to base. Each directory mirrors the layout of internal/templates
+// (base/..., features/...).
+func OverlayFS(base fs.FS, dirs ...string) fs.FS {
+	if len(dirs) == 0 {
+		return base
+	}
+	layers := make([]fs.FS, 0, len(dirs)+1)
+	for _, dir := range dirs {
+		layers = append(layers, os.DirFS(dir))
+	}
+	layers = append(layers, base)
+	return overlayFS{layers: layers}
+}
+
+func (o overlayFS) Open(name string) (fs.File, error) {
+	var lastErr error
+	for _, layer := range o.layers {
+		file, err := layer.Open(name)
+		if err == nil {
+			return file, nil
+		}
+		if !errors.Is(err, fs.ErrNotExist) {
+			return nil, err
+		}
+		lastErr = err
+	}
+	return nil, lastErr
+}
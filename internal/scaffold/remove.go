@@ -0,0 +1,278 @@
+package scaffold
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"os"
+	"path/filepath"
+	"sort"
+	"strings"
+
+	"github.com/Parapheen/fullkek-starter/internal/stacks"
+)
+
+// RemovalPlan lists the changes needed to strip a feature from a project.
+type RemovalPlan struct {
+	Feature   stacks.Feature
+	Selection stacks.Selection
+	// Replacement is the feature substituted in a single-choice category
+	// (for example payments-none), if any.
+	Replacement string
+	// Delete holds files owned solely by the removed feature.
+	Delete []string
+	// Rewrite holds shared files whose rendered content changes without the feature.
+	Rewrite []string
+	// Create holds files the new selection contributes that do not exist yet.
+	Create []string
+	// Modified holds planned paths that were edited since generation.
+	Modified []string
+
+	manifest Manifest
+	stack    stacks.Stack
+	rendered map[string]RenderedFile
+}
+
+// PlanRemoval works out how to remove featureID from the project at root
+// without touching anything on disk.
+func (g *Generator) PlanRemoval(ctx context.Context, root, featureID string) (RemovalPlan, error) {
+	manifest, err := ReadManifest(root)
+	if err != nil {
+		return RemovalPlan{}, err
+	}
+
+	feature, ok := stacks.FeatureByID(featureID)
+	if !ok {
+		return RemovalPlan{}, fmt.Errorf("unknown feature %q", featureID)
+	}
+	if !containsID(manifest.Selection[feature.CategoryID], featureID) {
+		return RemovalPlan{}, fmt.Errorf("feature %q is not part of this project", featureID)
+	}
+
+	if dependents := dependentFeatures(manifest.Selection, featureID); len(dependents) > 0 {
+		return RemovalPlan{}, fmt.Errorf("cannot remove %q: required by %s; remove those first", featureID, strings.Join(dependents, ", "))
+	}
+
+	selection, replacement, err := selectionWithout(manifest.Selection, feature)
+	if err != nil {
+		return RemovalPlan{}, err
+	}
+
+	stack, err := stacks.Compose(selection)
+	if err != nil {
+		return RemovalPlan{}, fmt.Errorf("compose stack without %q: %w", featureID, err)
+	}
+
+	files, err := g.Render(ctx, manifest.AppName, manifest.ModulePath, stack)
+	if err != nil {
+		return RemovalPlan{}, err
+	}
+	rendered := make(map[string]RenderedFile, len(files))
+	for _, file := range files {
+		rendered[file.Template.Destination] = file
+	}
+
+	plan := RemovalPlan{
+		Feature:     feature,
+		Selection:   selection,
+		Replacement: replacement,
+		manifest:    manifest,
+		stack:       stack,
+		rendered:    rendered,
+	}
+
+	for _, entry := range manifest.Files {
+		file, keep := rendered[entry.Path]
+		if keep && Checksum(file.Content) == entry.TemplateChecksum {
+			continue
+		}
+
+		state, err := diskState(root, entry)
+		if err != nil {
+			return RemovalPlan{}, err
+		}
+		if state == fileMissing {
+			continue
+		}
+		if state == fileModified {
+			plan.Modified = append(plan.Modified, entry.Path)
+		}
+		if keep {
+			plan.Rewrite = append(plan.Rewrite, entry.Path)
+		} else {
+			plan.Delete = append(plan.Delete, entry.Path)
+		}
+	}
+
+	for path := range rendered {
+		if _, tracked := manifest.File(path); !tracked {
+			plan.Create = append(plan.Create, path)
+		}
+	}
+	sort.Strings(plan.Create)
+
+	return plan, nil
+}
+
+// ApplyRemoval executes the plan. Hand-modified files are refused unless force is set.
+func (g *Generator) ApplyRemoval(ctx context.Context, root string, plan RemovalPlan, force bool) error {
+	if len(plan.Modified) > 0 && !force {
+		return fmt.Errorf("refusing to change files edited since generation (use --force to overwrite): %s", strings.Join(plan.Modified, ", "))
+	}
+
+	for _, path := range plan.Delete {
+		if err := ctx.Err(); err != nil {
+			return err
+		}
+		target := filepath.Join(root, path)
+		if err := os.Remove(target); err != nil && !errors.Is(err, os.ErrNotExist) {
+			return fmt.Errorf("remove %s: %w", path, err)
+		}
+		pruneEmptyDirs(root, filepath.Dir(target))
+	}
+
+	for _, path := range append(append([]string{}, plan.Rewrite...), plan.Create...) {
+		if err := ctx.Err(); err != nil {
+			return err
+		}
+		if err := writeRenderedFile(root, plan.rendered[path]); err != nil {
+			return err
+		}
+	}
+
+	if err := runGoModTidy(ctx, root); err != nil {
+		// As in Generate, an offline tidy is not fatal.
+	}
+
+	changed := make(map[string]bool, len(plan.Delete)+len(plan.Rewrite)+len(plan.Create))
+	for _, path := range plan.Delete {
+		changed[path] = true
+	}
+
+	manifest, err := NewManifest(root, plan.manifest.AppName, plan.manifest.ModulePath, plan.stack, nil)
+	if err != nil {
+		return err
+	}
+	for _, path := range append(append([]string{}, plan.Rewrite...), plan.Create...) {
+		changed[path] = true
+		entry, err := manifestEntry(root, plan.rendered[path])
+		if err != nil {
+			return err
+		}
+		manifest.Files = append(manifest.Files, entry)
+	}
+	// Untouched files keep their recorded checksums so later edits stay detectable.
+	for _, entry := range plan.manifest.Files {
+		if _, keep := plan.rendered[entry.Path]; keep && !changed[entry.Path] {
+			entry.Feature = plan.rendered[entry.Path].Template.Feature
+			manifest.Files = append(manifest.Files, entry)
+		}
+	}
+
+	return WriteManifest(root, manifest)
+}
+
+// dependentFeatures lists selected features that declare featureID as a dependency.
+func dependentFeatures(sel stacks.Selection, featureID string) []string {
+	dependents := make([]string, 0)
+	for _, ids := range sel {
+		for _, id := range ids {
+			if containsID(stacks.FeatureDependencies(id), featureID) {
+				dependents = append(dependents, id)
+			}
+		}
+	}
+	sort.Strings(dependents)
+	return dependents
+}
+
+// selectionWithout drops the feature from sel, substituting the category's
+// "none" feature where the category offers one.
+func selectionWithout(sel stacks.Selection, feature stacks.Feature) (stacks.Selection, string, error) {
+	out := stacks.CloneSelection(sel)
+
+	remaining := make([]string, 0, len(out[feature.CategoryID]))
+	for _, id := range out[feature.CategoryID] {
+		if id != feature.ID {
+			remaining = append(remaining, id)
+		}
+	}
+
+	replacement := ""
+	if len(remaining) == 0 {
+		for _, candidate := range stacks.FeaturesForCategory(feature.CategoryID) {
+			if strings.HasSuffix(candidate.ID, "-none") {
+				replacement = candidate.ID
+				break
+			}
+		}
+		if replacement == feature.ID {
+			return nil, "", fmt.Errorf("feature %q is already the empty choice for its category", feature.ID)
+		}
+		if replacement != "" {
+			remaining = append(remaining, replacement)
+		}
+	}
+
+	if len(remaining) == 0 {
+		for _, category := range stacks.Categories() {
+			if category.ID == feature.CategoryID && category.Required {
+				return nil, "", fmt.Errorf("cannot remove %q: category %q requires a selection; switch to another feature instead", feature.ID, category.Name)
+			}
+		}
+		delete(out, feature.CategoryID)
+	} else {
+		out[feature.CategoryID] = remaining
+	}
+
+	return out, replacement, nil
+}
+
+type fileState int
+
+const (
+	fileUnchanged fileState = iota
+	fileModified
+	fileMissing
+)
+
+func diskState(root string, entry ManifestFile) (fileState, error) {
+	checksum, err := FileChecksum(filepath.Join(root, entry.Path))
+	if err != nil {
+		if errors.Is(err, os.ErrNotExist) {
+			return fileMissing, nil
+		}
+		return 0, err
+	}
+	if checksum != entry.Checksum {
+		return fileModified, nil
+	}
+	return fileUnchanged, nil
+}
+
+// pruneEmptyDirs removes dir and its parents while they are empty, stopping at root.
+func pruneEmptyDirs(root, dir string) {
+	for {
+		rel, err := filepath.Rel(root, dir)
+		if err != nil || rel == "." || strings.HasPrefix(rel, "..") {
+			return
+		}
+		entries, err := os.ReadDir(dir)
+		if err != nil || len(entries) > 0 {
+			return
+		}
+		if err := os.Remove(dir); err != nil {
+			return
+		}
+		dir = filepath.Dir(dir)
+	}
+}
+
+func containsID(ids []string, id string) bool {
+	for _, candidate := range ids {
+		if candidate == id {
+			return true
+		}
+	}
+	return false
+}
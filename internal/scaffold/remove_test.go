@@ -0,0 +1,113 @@
+package scaffold
+
+import (
+	"context"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/Parapheen/fullkek-starter/internal/stacks"
+)
+
+func generatePaymentsProject(t *testing.T) string {
+	t.Helper()
+
+	sel := stacks.MergeSelections(stacks.DefaultSelection(), stacks.Selection{
+		stacks.CategoryDatabase: {"database-sqlite"},
+		stacks.CategoryPayments: {"payments-yookassa"},
+	})
+	stack, err := stacks.Compose(sel)
+	if err != nil {
+		t.Fatalf("compose stack: %v", err)
+	}
+
+	destination := filepath.Join(t.TempDir(), "shop")
+	err = DefaultGenerator().Generate(context.Background(), Options{
+		AppName:     "shop",
+		ModulePath:  "example.com/shop",
+		Destination: destination,
+		Stack:       stack,
+		Git:         GitOptions{Mode: GitNone},
+	})
+	if err != nil {
+		t.Fatalf("generate project: %v", err)
+	}
+	return destination
+}
+
+func TestRemoveFeatureDeletesOwnedFilesAndRerendersShared(t *testing.T) {
+	t.Parallel()
+
+	root := generatePaymentsProject(t)
+	generator := DefaultGenerator()
+
+	plan, err := generator.PlanRemoval(context.Background(), root, "payments-yookassa")
+	if err != nil {
+		t.Fatalf("plan removal: %v", err)
+	}
+	if plan.Replacement != "payments-none" {
+		t.Fatalf("expected payments-none replacement, got %q", plan.Replacement)
+	}
+	if err := generator.ApplyRemoval(context.Background(), root, plan, false); err != nil {
+		t.Fatalf("apply removal: %v", err)
+	}
+
+	if _, err := os.Stat(filepath.Join(root, "internal/infrastructure/payments")); !os.IsNotExist(err) {
+		t.Fatalf("expected payments package to be removed, got: %v", err)
+	}
+
+	app, err := os.ReadFile(filepath.Join(root, "internal/app/app.go"))
+	if err != nil {
+		t.Fatalf("read app.go: %v", err)
+	}
+	if strings.Contains(string(app), "paymentsinfra") {
+		t.Fatal("expected app.go to be re-rendered without payments wiring")
+	}
+
+	manifest, err := ReadManifest(root)
+	if err != nil {
+		t.Fatalf("read manifest: %v", err)
+	}
+	if got := manifest.Selection[stacks.CategoryPayments]; len(got) != 1 || got[0] != "payments-none" {
+		t.Fatalf("expected manifest selection to record payments-none, got %v", got)
+	}
+	if _, ok := manifest.File("internal/domain/payment/model.go"); ok {
+		t.Fatal("expected removed files to be dropped from the manifest")
+	}
+}
+
+func TestRemoveFeatureRefusesDependedOnFeature(t *testing.T) {
+	t.Parallel()
+
+	root := generatePaymentsProject(t)
+
+	_, err := DefaultGenerator().PlanRemoval(context.Background(), root, "database-sqlite")
+	if err == nil || !strings.Contains(err.Error(), "required by payments-yookassa") {
+		t.Fatalf("expected dependency error, got: %v", err)
+	}
+}
+
+func TestRemoveFeatureRefusesModifiedFilesWithoutForce(t *testing.T) {
+	t.Parallel()
+
+	root := generatePaymentsProject(t)
+	handlers := filepath.Join(root, "internal/transport/http/payment_handlers.go")
+	if err := os.WriteFile(handlers, []byte("package http\n"), 0o644); err != nil {
+		t.Fatalf("edit handlers: %v", err)
+	}
+
+	generator := DefaultGenerator()
+	plan, err := generator.PlanRemoval(context.Background(), root, "payments-yookassa")
+	if err != nil {
+		t.Fatalf("plan removal: %v", err)
+	}
+
+	err = generator.ApplyRemoval(context.Background(), root, plan, false)
+	if err == nil || !strings.Contains(err.Error(), "payment_handlers.go") {
+		t.Fatalf("expected refusal naming the modified file, got: %v", err)
+	}
+	if _, err := os.Stat(handlers); err != nil {
+		t.Fatalf("expected modified file to be kept, got: %v", err)
+	}
+}
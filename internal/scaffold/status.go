@@ -0,0 +1,109 @@
+package scaffold
+
+import (
+	"context"
+	"fmt"
+	"os"
+	"path/filepath"
+	"sort"
+
+	"github.com/Parapheen/fullkek-starter/internal/stacks"
+)
+
+// FileStatus classifies a generated file against the manifest and current templates.
+type FileStatus string
+
+const (
+	// StatusUnchanged means the file matches what was generated and the template has not moved on.
+	StatusUnchanged FileStatus = "unchanged"
+	// StatusModified means the file was edited since generation.
+	StatusModified FileStatus = "modified"
+	// StatusDeleted means the generated file no longer exists.
+	StatusDeleted FileStatus = "deleted"
+	// StatusOutdated means the file is pristine but the template now renders differently,
+	// or the template is new since generation.
+	StatusOutdated FileStatus = "newer-template-available"
+)
+
+// FileReport describes the state of one generated file.
+type FileReport struct {
+	Path    string
+	Feature string
+	Status  FileStatus
+	// Disk holds the current file content, if it exists.
+	Disk []byte
+	// Rendered holds the template rendered with the recorded selection, if the
+	// current templates still produce the file.
+	Rendered []byte
+	// PostProcessed marks files rewritten after rendering (such as go.mod by
+	// go mod tidy). Their disk content is checked against the recorded
+	// checksum, but it cannot be diffed line by line against the template.
+	PostProcessed bool
+}
+
+// Status re-renders the project's recorded selection in memory and compares
+// every generated file with the manifest and the disk.
+func (g *Generator) Status(ctx context.Context, root string) (Manifest, []FileReport, error) {
+	manifest, err := ReadManifest(root)
+	if err != nil {
+		return Manifest{}, nil, err
+	}
+
+	stack, err := stacks.Compose(manifest.Selection)
+	if err != nil {
+		return Manifest{}, nil, fmt.Errorf("compose recorded selection: %w", err)
+	}
+
+	files, err := g.Render(ctx, manifest.AppName, manifest.ModulePath, stack)
+	if err != nil {
+		return Manifest{}, nil, err
+	}
+	rendered := make(map[string]RenderedFile, len(files))
+	for _, file := range files {
+		rendered[file.Template.Destination] = file
+	}
+
+	reports := make([]FileReport, 0, len(manifest.Files))
+	for _, entry := range manifest.Files {
+		report := FileReport{Path: entry.Path, Feature: entry.Feature, PostProcessed: entry.Checksum != entry.TemplateChecksum}
+		file, stillRendered := rendered[entry.Path]
+		if stillRendered {
+			report.Rendered = file.Content
+		}
+
+		disk, err := os.ReadFile(filepath.Join(root, entry.Path))
+		switch {
+		case os.IsNotExist(err):
+			report.Status = StatusDeleted
+		case err != nil:
+			return Manifest{}, nil, fmt.Errorf("read %s: %w", entry.Path, err)
+		case Checksum(disk) != entry.Checksum:
+			report.Disk = disk
+			report.Status = StatusModified
+		case !stillRendered || Checksum(file.Content) != entry.TemplateChecksum:
+			report.Disk = disk
+			report.Status = StatusOutdated
+		default:
+			report.Disk = disk
+			report.Status = StatusUnchanged
+		}
+		reports = append(reports, report)
+	}
+
+	for path, file := range rendered {
+		if _, tracked := manifest.File(path); tracked {
+			continue
+		}
+		report := FileReport{Path: path, Feature: file.Template.Feature, Status: StatusOutdated, Rendered: file.Content}
+		if disk, err := os.ReadFile(filepath.Join(root, path)); err == nil {
+			report.Disk = disk
+		}
+		reports = append(reports, report)
+	}
+
+	sort.Slice(reports, func(i, j int) bool {
+		return reports[i].Path < reports[j].Path
+	})
+
+	return manifest, reports, nil
+}
@@ -0,0 +1,129 @@
+package scaffold
+
+import (
+	"context"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/Parapheen/fullkek-starter/internal/stacks"
+)
+
+func TestStatusClassifiesGeneratedFiles(t *testing.T) {
+	t.Parallel()
+
+	stack, err := stacks.Compose(stacks.DefaultSelection())
+	if err != nil {
+		t.Fatalf("compose stack: %v", err)
+	}
+
+	root := filepath.Join(t.TempDir(), "my-app")
+	generator := DefaultGenerator()
+	err = generator.Generate(context.Background(), Options{
+		AppName:     "my-app",
+		ModulePath:  "example.com/my-app",
+		Destination: root,
+		Stack:       stack,
+		Git:         GitOptions{Mode: GitNone},
+	})
+	if err != nil {
+		t.Fatalf("generate project: %v", err)
+	}
+
+	if err := os.WriteFile(filepath.Join(root, "README.md"), []byte("# mine\n"), 0o644); err != nil {
+		t.Fatalf("edit README: %v", err)
+	}
+	if err := os.Remove(filepath.Join(root, ".air.toml")); err != nil {
+		t.Fatalf("remove .air.toml: %v", err)
+	}
+
+	// Pretend the Makefile template changed after generation.
+	manifest, err := ReadManifest(root)
+	if err != nil {
+		t.Fatalf("read manifest: %v", err)
+	}
+	for i := range manifest.Files {
+		if manifest.Files[i].Path == "Makefile" {
+			manifest.Files[i].TemplateChecksum = Checksum([]byte("old template"))
+		}
+	}
+	if err := WriteManifest(root, manifest); err != nil {
+		t.Fatalf("write manifest: %v", err)
+	}
+
+	_, reports, err := generator.Status(context.Background(), root)
+	if err != nil {
+		t.Fatalf("status: %v", err)
+	}
+
+	got := map[string]FileStatus{}
+	for _, report := range reports {
+		got[report.Path] = report.Status
+	}
+
+	want := map[string]FileStatus{
+		"README.md":                         StatusModified,
+		".air.toml":                         StatusDeleted,
+		"Makefile":                          StatusOutdated,
+		"internal/transport/http/router.go": StatusUnchanged,
+	}
+	for path, status := range want {
+		if got[path] != status {
+			t.Fatalf("%s: expected %s, got %s", path, status, got[path])
+		}
+	}
+}
+
+func TestUnifiedDiffReportsChangedLines(t *testing.T) {
+	t.Parallel()
+
+	a := []byte("one\ntwo\nthree\n")
+	b := []byte("one\n2\nthree\nfour\n")
+
+	diff := UnifiedDiff("a", "b", a, b)
+	for _, want := range []string{"@@ -1,3 +1,4 @@", "-two", "+2", "+four", " three"} {
+		if !strings.Contains(diff, want) {
+			t.Fatalf("expected %q in diff, got:\n%s", want, diff)
+		}
+	}
+
+	if UnifiedDiff("a", "b", a, a) != "" {
+		t.Fatal("expected empty diff for identical input")
+	}
+}
+
+func TestStatusReportsFreshProjectUnchanged(t *testing.T) {
+	t.Parallel()
+
+	stack, err := stacks.Compose(stacks.DefaultSelection())
+	if err != nil {
+		t.Fatalf("compose stack: %v", err)
+	}
+
+	root := filepath.Join(t.TempDir(), "my-app")
+	generator := DefaultGenerator()
+	err = generator.Generate(context.Background(), Options{
+		AppName:     "my-app",
+		ModulePath:  "example.com/my-app",
+		Destination: root,
+		Stack:       stack,
+		Git:         GitOptions{Mode: GitNone},
+	})
+	if err != nil {
+		t.Fatalf("generate project: %v", err)
+	}
+
+	_, reports, err := generator.Status(context.Background(), root)
+	if err != nil {
+		t.Fatalf("status: %v", err)
+	}
+	if len(reports) == 0 {
+		t.Fatal("expected reports for the generated files")
+	}
+	for _, report := range reports {
+		if report.Status != StatusUnchanged {
+			t.Errorf("%s: expected %s, got %s", report.Path, StatusUnchanged, report.Status)
+		}
+	}
+}
@@ -9,8 +9,10 @@ const (
 	CategoryDatabase       = "database"
 	CategoryAuth           = "auth"
 	CategoryOAuthProviders = "oauth-providers"
+	CategoryAccount        = "account"
 	CategoryEmail          = "email"
 	CategoryPayments       = "payments"
+	CategoryBilling        = "billing"
 	CategoryDeploy         = "deploy"
 )
 
@@ -42,8 +44,8 @@ var categories = []FeatureCategory{
 	{
 		ID:            CategoryAuth,
 		Name:          "Authentication",
-		Description:   "Optional authentication providers.",
-		AllowMultiple: false,
+		Description:   "Optional sign-in methods; pick several to let users link them to one account.",
+		AllowMultiple: true,
 	},
 	{
 		ID:            CategoryOAuthProviders,
@@ -51,6 +53,12 @@ var categories = []FeatureCategory{
 		Description:   "Select one or more OAuth identity providers.",
 		AllowMultiple: true,
 	},
+	{
+		ID:            CategoryAccount,
+		Name:          "Account features",
+		Description:   "Optional extras for signed-in users; each needs a sign-in method.",
+		AllowMultiple: true,
+	},
 	{
 		ID:            CategoryEmail,
 		Name:          "Email",
@@ -63,6 +71,12 @@ var categories = []FeatureCategory{
 		Description:   "Payment processing integration.",
 		AllowMultiple: false,
 	},
+	{
+		ID:            CategoryBilling,
+		Name:          "Billing",
+		Description:   "Recurring billing on top of the payment provider.",
+		AllowMultiple: false,
+	},
 	{
 		ID:            CategoryDeploy,
 		Name:          "Deployment",
@@ -78,6 +92,12 @@ var featureCatalog = []Feature{
 		Name:        "HTMX",
 		Description: "Server-driven interactions with HTMX requests and swaps.",
 		Tags:        []string{"HTMX"},
+		Routes: []string{
+			"GET /api/counter",
+			"POST /api/increment",
+			"GET /api/todos",
+			"GET /fragments/toast/success (Basecoat and DaisyUI only)",
+		},
 		Directories: []string{
 			"public/assets/scripts",
 		},
@@ -94,6 +114,15 @@ var featureCatalog = []Feature{
 		Name:        "Tailwind CSS",
 		Description: "Utility-first styling powered by standalone Tailwind CLI binary.",
 		Tags:        []string{"Tailwind"},
+		MakeTargets: []string{
+			"tailwind-install",
+			"tailwind-watch",
+			"tailwind-build",
+		},
+		Tools: []string{
+			"tailwindcss (downloaded into ./bin by make tailwind-install)",
+			"curl",
+		},
 		Directories: []string{
 			"web/assets/styles/tokens",
 		},
@@ -109,6 +138,7 @@ var featureCatalog = []Feature{
 			{
 				Source:      "features/styling/tailwind/web/templates/pages/index.html.tmpl",
 				Destination: "web/templates/pages/index.html",
+				Delims:      BracketDelims,
 			},
 		},
 	},
@@ -118,6 +148,15 @@ var featureCatalog = []Feature{
 		Name:        "Tailwind CSS + Basecoat",
 		Description: "Tailwind standalone CLI with Basecoat component library via CDN.",
 		Tags:        []string{"Tailwind", "Basecoat"},
+		MakeTargets: []string{
+			"tailwind-install",
+			"tailwind-watch",
+			"tailwind-build",
+		},
+		Tools: []string{
+			"tailwindcss (downloaded into ./bin by make tailwind-install)",
+			"curl",
+		},
 		Directories: []string{
 			"web/assets/styles/tokens",
 		},
@@ -133,6 +172,7 @@ var featureCatalog = []Feature{
 			{
 				Source:      "features/styling/tailwind_basecoat/web/templates/pages/index.html.tmpl",
 				Destination: "web/templates/pages/index.html",
+				Delims:      BracketDelims,
 			},
 		},
 	},
@@ -142,6 +182,10 @@ var featureCatalog = []Feature{
 		Name:        "DaisyUI standalone",
 		Description: "Tailwind standalone CLI plus DaisyUI fast script generated bundle.",
 		Tags:        []string{"DaisyUI"},
+		Tools: []string{
+			"curl",
+			"bash (runs the daisyui.com/fast installer on first make go)",
+		},
 		Directories: []string{
 			"public/assets/styles",
 		},
@@ -161,6 +205,7 @@ var featureCatalog = []Feature{
 			{
 				Source:      "features/styling/daisyui/web/templates/pages/index.html.tmpl",
 				Destination: "web/templates/pages/index.html",
+				Delims:      BracketDelims,
 			},
 		},
 	},
@@ -170,6 +215,15 @@ var featureCatalog = []Feature{
 		Name:        "net/http",
 		Description: "Standard library HTTP server with a ServeMux and HTML response.",
 		Tags:        []string{"net/http"},
+		Routes: []string{
+			"GET /",
+			"GET /healthz",
+			"POST /demo/echo",
+		},
+		Env: []string{
+			"TRUSTED_PROXIES",
+			"TRUSTED_PROXY_HEADER",
+		},
 		Templates: []Template{
 			{
 				Source:      "features/http/standard/internal/transport/http/server.go.tmpl",
@@ -187,6 +241,15 @@ var featureCatalog = []Feature{
 		Name:        "Chi",
 		Description: "Go-chi router with middleware-ready structure.",
 		Tags:        []string{"chi"},
+		Routes: []string{
+			"GET /",
+			"GET /healthz",
+			"POST /demo/echo",
+		},
+		Env: []string{
+			"TRUSTED_PROXIES",
+			"TRUSTED_PROXY_HEADER",
+		},
 		Templates: []Template{
 			{
 				Source:      "features/http/chi/internal/transport/http/server.go.tmpl",
@@ -211,6 +274,20 @@ var featureCatalog = []Feature{
 		Name:        "SQLite",
 		Description: "Preconfigured SQLite helper powered by sqlx.",
 		Tags:        []string{"database", "SQLite", "sqlx"},
+		Env: []string{
+			"SQLITE_DSN",
+		},
+		MakeTargets: []string{
+			"goose-install",
+			"migrate-create",
+			"migrate-up",
+			"migrate-down",
+			"migrate-status",
+		},
+		Tools: []string{
+			"goose (installed into ./bin by make goose-install)",
+			"cgo toolchain for mattn/go-sqlite3",
+		},
 		Directories: []string{
 			"internal/infrastructure/persistence",
 		},
@@ -219,6 +296,10 @@ var featureCatalog = []Feature{
 				Source:      "features/database/sqlite/internal/infrastructure/persistence/sqlite.go.tmpl",
 				Destination: "internal/infrastructure/persistence/sqlite.go",
 			},
+			{
+				Source:      "features/database/sqlite/internal/infrastructure/persistence/tx.go.tmpl",
+				Destination: "internal/infrastructure/persistence/tx.go",
+			},
 		},
 	},
 	// --- Authentication ---
@@ -234,7 +315,23 @@ var featureCatalog = []Feature{
 		CategoryID:  CategoryAuth,
 		Name:        "OAuth2",
 		Description: "Login with OAuth2 providers using server-side sessions.",
-		Tags:        []string{"auth", "oauth2"},
+		Tags:        []string{"auth", "accounts", "oauth2"},
+		Routes: []string{
+			"GET /login",
+			"GET /auth/{provider}",
+			"GET /auth/{provider}/callback",
+			"GET /logout",
+			"GET /profile",
+			"GET /profile/sessions",
+			"POST /profile/sessions/revoke",
+			"POST /profile/sessions/revoke-others",
+			"POST /profile/providers/disconnect",
+		},
+		Env: []string{
+			"OAUTH_CALLBACK_BASE",
+			"SESSION_COOKIE_NAME",
+			"SESSION_TTL_DAYS",
+		},
 		Directories: []string{
 			"db/migrations",
 			"internal/app/auth",
@@ -246,255 +343,637 @@ var featureCatalog = []Feature{
 			"internal/transport/http",
 			"web/templates/pages",
 		},
-		Templates: []Template{
-			// HTTP transport
-			{
+		Templates: authTemplates(
+			Template{
+				Source:      "features/auth/oauth2/internal/application/auth/oauth.go.tmpl",
+				Destination: "internal/app/auth/oauth.go",
+			},
+			Template{
+				Source:      "features/auth/oauth2/internal/application/auth/oauth_test.go.tmpl",
+				Destination: "internal/app/auth/oauth_test.go",
+			},
+			Template{
 				Source:      "features/auth/oauth2/internal/transport/http/oauth_handlers.go.tmpl",
 				Destination: "internal/transport/http/oauth_handlers.go",
 			},
-			{
-				Source:      "features/auth/oauth2/internal/transport/http/render.go.tmpl",
-				Destination: "internal/transport/http/render.go",
+			Template{
+				Source:      "features/auth/oauth2/db/migrations/0003_create_user_identities.sql.tmpl",
+				Destination: "db/migrations/0003_create_user_identities.sql",
 			},
-			{
-				Source:      "features/auth/oauth2/internal/transport/http/auth_middleware.go.tmpl",
-				Destination: "internal/transport/http/auth_middleware.go",
+		),
+	},
+	{
+		ID:          "auth-magic-link",
+		CategoryID:  CategoryAuth,
+		Name:        "Magic Link",
+		Description: "Passwordless sign-in via one-time emailed link (logged in development).",
+		Tags:        []string{"auth", "accounts", "magic-link", "passwordless"},
+		Routes: []string{
+			"GET /login",
+			"POST /login",
+			"GET /auth/magic/verify",
+			"GET /logout",
+			"GET /profile",
+			"GET /profile/sessions",
+			"POST /profile/sessions/revoke",
+			"POST /profile/sessions/revoke-others",
+		},
+		Env: []string{
+			"MAGIC_LINK_BASE_URL",
+			"MAGIC_LINK_TTL_MINUTES",
+			"SESSION_COOKIE_NAME",
+			"SESSION_TTL_DAYS",
+		},
+		Directories: []string{
+			"db/migrations",
+			"internal/app/auth",
+			"internal/domain/magiclink",
+			"internal/domain/session",
+			"internal/domain/user",
+			"internal/infrastructure/persistence",
+			"internal/transport/http",
+			"web/templates/pages",
+		},
+		Templates: authTemplates(
+			Template{
+				Source:      "features/auth/magic-link/internal/application/auth/magic_link.go.tmpl",
+				Destination: "internal/app/auth/magic_link.go",
+			},
+			Template{
+				Source:      "features/auth/magic-link/internal/transport/http/magic_link_handlers.go.tmpl",
+				Destination: "internal/transport/http/magic_link_handlers.go",
+			},
+			Template{
+				Source:      "features/auth/magic-link/internal/domain/magiclink/model.go.tmpl",
+				Destination: "internal/domain/magiclink/model.go",
+			},
+			Template{
+				Source:      "features/auth/magic-link/internal/domain/magiclink/repository.go.tmpl",
+				Destination: "internal/domain/magiclink/repository.go",
+			},
+			Template{
+				Source:      "features/auth/magic-link/internal/infrastructure/persistence/magic_link_token_repository_sqlite.go.tmpl",
+				Destination: "internal/infrastructure/persistence/magic_link_token_repository_sqlite.go",
+			},
+			Template{
+				Source:      "features/auth/magic-link/db/migrations/0009_create_magic_link_tokens.sql.tmpl",
+				Destination: "db/migrations/0009_create_magic_link_tokens.sql",
+			},
+		),
+	},
+	{
+		ID:          "auth-password",
+		CategoryID:  CategoryAuth,
+		Name:        "Password",
+		Description: "Email and password sign-in with Argon2id hashing, email verification and password reset.",
+		Tags:        []string{"auth", "accounts", "password"},
+		Routes: []string{
+			"GET /login",
+			"POST /login/password",
+			"GET /register",
+			"POST /register",
+			"GET /register/verify",
+			"GET /password/forgot",
+			"POST /password/forgot",
+			"GET /password/reset",
+			"POST /password/reset",
+			"GET /logout",
+			"GET /profile",
+			"GET /profile/sessions",
+			"POST /profile/sessions/revoke",
+			"POST /profile/sessions/revoke-others",
+		},
+		Env: []string{
+			"PASSWORD_LINK_BASE_URL",
+			"PASSWORD_ARGON2_MEMORY_KIB",
+			"PASSWORD_ARGON2_ITERATIONS",
+			"PASSWORD_ARGON2_PARALLELISM",
+			"PASSWORD_MIN_LENGTH",
+			"PASSWORD_BLOCKLIST_FILE",
+			"PASSWORD_VERIFY_TTL",
+			"PASSWORD_RESET_TTL",
+			"PASSWORD_MAX_FAILURES_PER_ACCOUNT",
+			"PASSWORD_MAX_FAILURES_PER_IP",
+			"PASSWORD_FAILURE_WINDOW",
+			"SESSION_COOKIE_NAME",
+			"SESSION_TTL_DAYS",
+		},
+		Directories: []string{
+			"db/migrations",
+			"internal/app/auth",
+			"internal/domain/password",
+			"internal/domain/session",
+			"internal/domain/user",
+			"internal/infrastructure/persistence",
+			"internal/transport/http",
+			"web/templates/pages",
+		},
+		Templates: authTemplates(
+			Template{
+				Source:      "features/auth/password/internal/application/auth/password.go.tmpl",
+				Destination: "internal/app/auth/password.go",
+			},
+			Template{
+				Source:      "features/auth/password/internal/application/auth/login_throttle.go.tmpl",
+				Destination: "internal/app/auth/login_throttle.go",
+			},
+			Template{
+				Source:      "features/auth/password/internal/application/auth/password_test.go.tmpl",
+				Destination: "internal/app/auth/password_test.go",
+			},
+			Template{
+				Source:      "features/auth/password/internal/transport/http/password_handlers.go.tmpl",
+				Destination: "internal/transport/http/password_handlers.go",
+			},
+			Template{
+				Source:      "features/auth/password/internal/domain/password/hash.go.tmpl",
+				Destination: "internal/domain/password/hash.go",
+			},
+			Template{
+				Source:      "features/auth/password/internal/domain/password/policy.go.tmpl",
+				Destination: "internal/domain/password/policy.go",
+			},
+			Template{
+				Source:      "features/auth/password/internal/domain/password/common_passwords.txt.tmpl",
+				Destination: "internal/domain/password/common_passwords.txt",
+				Verbatim:    true,
+			},
+			Template{
+				Source:      "features/auth/password/internal/domain/password/model.go.tmpl",
+				Destination: "internal/domain/password/model.go",
+			},
+			Template{
+				Source:      "features/auth/password/internal/domain/password/repository.go.tmpl",
+				Destination: "internal/domain/password/repository.go",
+			},
+			Template{
+				Source:      "features/auth/password/internal/infrastructure/persistence/password_repository_sqlite.go.tmpl",
+				Destination: "internal/infrastructure/persistence/password_repository_sqlite.go",
+			},
+			Template{
+				Source:      "features/auth/password/web/templates/pages/password.html.tmpl",
+				Destination: "web/templates/pages/password.html",
+				Delims:      BracketDelims,
+			},
+			Template{
+				Source:      "features/auth/password/db/migrations/0010_create_password_credentials.sql.tmpl",
+				Destination: "db/migrations/0010_create_password_credentials.sql",
+			},
+		),
+	},
+	{
+		ID:          "auth-passkeys",
+		CategoryID:  CategoryAuth,
+		Name:        "Passkeys",
+		Description: "WebAuthn passkey sign-in with email setup links and several passkeys per account.",
+		Tags:        []string{"auth", "accounts", "passkeys", "passwordless"},
+		Routes: []string{
+			"GET /login",
+			"POST /login/passkey/options",
+			"POST /login/passkey",
+			"GET /passkeys/setup",
+			"POST /passkeys/setup",
+			"GET /passkeys/setup/verify",
+			"POST /passkeys/setup/verify",
+			"POST /passkeys/setup/options",
+			"GET /profile/passkeys",
+			"POST /profile/passkeys/options",
+			"POST /profile/passkeys/add",
+			"POST /profile/passkeys/delete",
+			"GET /logout",
+			"GET /profile",
+			"GET /profile/sessions",
+			"POST /profile/sessions/revoke",
+			"POST /profile/sessions/revoke-others",
+		},
+		Env: []string{
+			"PASSKEY_RP_ID",
+			"PASSKEY_RP_NAME",
+			"PASSKEY_ORIGINS",
+			"PASSKEY_LINK_BASE_URL",
+			"SESSION_COOKIE_NAME",
+			"SESSION_TTL_DAYS",
+		},
+		Directories: []string{
+			"db/migrations",
+			"internal/app/auth",
+			"internal/domain/passkey",
+			"internal/domain/session",
+			"internal/domain/user",
+			"internal/infrastructure/persistence",
+			"internal/transport/http",
+			"public/assets/scripts",
+			"web/templates/pages",
+		},
+		Templates: authTemplates(
+			Template{
+				Source:      "features/auth/passkeys/internal/application/auth/passkeys.go.tmpl",
+				Destination: "internal/app/auth/passkeys.go",
+			},
+			Template{
+				Source:      "features/auth/passkeys/internal/application/auth/passkeys_test.go.tmpl",
+				Destination: "internal/app/auth/passkeys_test.go",
+			},
+			Template{
+				Source:      "features/auth/passkeys/internal/transport/http/passkey_handlers.go.tmpl",
+				Destination: "internal/transport/http/passkey_handlers.go",
+			},
+			Template{
+				Source:      "features/auth/passkeys/internal/domain/passkey/model.go.tmpl",
+				Destination: "internal/domain/passkey/model.go",
+			},
+			Template{
+				Source:      "features/auth/passkeys/internal/domain/passkey/repository.go.tmpl",
+				Destination: "internal/domain/passkey/repository.go",
 			},
-			// Web templates
+			Template{
+				Source:      "features/auth/passkeys/internal/infrastructure/persistence/passkey_repository_sqlite.go.tmpl",
+				Destination: "internal/infrastructure/persistence/passkey_repository_sqlite.go",
+			},
+			Template{
+				Source:      "features/auth/passkeys/web/templates/pages/passkeys.html.tmpl",
+				Destination: "web/templates/pages/passkeys.html",
+				Delims:      BracketDelims,
+			},
+			Template{
+				Source:      "features/auth/passkeys/public/assets/scripts/passkeys.js.tmpl",
+				Destination: "public/assets/scripts/passkeys.js",
+			},
+			Template{
+				Source:      "features/auth/passkeys/db/migrations/0012_create_passkeys.sql.tmpl",
+				Destination: "db/migrations/0012_create_passkeys.sql",
+			},
+		),
+	},
+	// --- OAuth Providers ---
+	{
+		ID:          "oauth-github",
+		CategoryID:  CategoryOAuthProviders,
+		Name:        "GitHub",
+		Description: "GitHub OAuth2 identity provider.",
+		Tags:        []string{"github"},
+		Routes: []string{
+			"GET /auth/github",
+			"GET /auth/github/callback",
+		},
+		Env: []string{
+			"GITHUB_CLIENT_ID",
+			"GITHUB_CLIENT_SECRET",
+		},
+		Directories: []string{
+			"internal/infrastructure/auth",
+		},
+		Templates: []Template{
 			{
-				Source:      "features/auth/oauth2/web/templates/pages/profile.html.tmpl",
-				Destination: "web/templates/pages/profile.html",
+				Source:      "features/oauth/github/internal/infrastructure/auth/github_oauth.go.tmpl",
+				Destination: "internal/infrastructure/auth/github_oauth.go",
 			},
+		},
+	},
+	{
+		ID:          "oauth-google",
+		CategoryID:  CategoryOAuthProviders,
+		Name:        "Google",
+		Description: "Google OAuth2 identity provider.",
+		Tags:        []string{"google"},
+		Routes: []string{
+			"GET /auth/google",
+			"GET /auth/google/callback",
+		},
+		Env: []string{
+			"GOOGLE_CLIENT_ID",
+			"GOOGLE_CLIENT_SECRET",
+		},
+		Directories: []string{
+			"internal/infrastructure/auth",
+		},
+		Templates: []Template{
 			{
-				Source:      "features/auth/oauth2/web/templates/pages/login.html.tmpl",
-				Destination: "web/templates/pages/login.html",
+				Source:      "features/oauth/google/internal/infrastructure/auth/google_oauth.go.tmpl",
+				Destination: "internal/infrastructure/auth/google_oauth.go",
 			},
-			// Domain: user
+		},
+	},
+	{
+		ID:          "oauth-yandex",
+		CategoryID:  CategoryOAuthProviders,
+		Name:        "Yandex",
+		Description: "Yandex OAuth2 identity provider.",
+		Tags:        []string{"yandex"},
+		Routes: []string{
+			"GET /auth/yandex",
+			"GET /auth/yandex/callback",
+		},
+		Env: []string{
+			"YANDEX_CLIENT_ID",
+			"YANDEX_CLIENT_SECRET",
+		},
+		Directories: []string{
+			"internal/infrastructure/auth",
+		},
+		Templates: []Template{
 			{
-				Source:      "features/auth/oauth2/internal/domain/user/model.go.tmpl",
-				Destination: "internal/domain/user/model.go",
+				Source:      "features/oauth/yandex/internal/infrastructure/auth/yandex_oauth.go.tmpl",
+				Destination: "internal/infrastructure/auth/yandex_oauth.go",
 			},
+		},
+	},
+	{
+		ID:          "oauth-oidc",
+		CategoryID:  CategoryOAuthProviders,
+		Name:        "OpenID Connect",
+		Description: "Any OpenID Connect issuer (Keycloak, Auth0, Okta...) configured by issuer URL, with discovery, PKCE and ID token validation.",
+		Tags:        []string{"oidc", "sso"},
+		Routes: []string{
+			"GET /auth/oidc",
+			"GET /auth/oidc/callback",
+		},
+		Env: []string{
+			"OIDC_ISSUER_URL",
+			"OIDC_CLIENT_ID",
+			"OIDC_CLIENT_SECRET",
+			"OIDC_SCOPES",
+		},
+		Directories: []string{
+			"internal/infrastructure/auth",
+		},
+		Templates: []Template{
 			{
-				Source:      "features/auth/oauth2/internal/domain/user/repository.go.tmpl",
-				Destination: "internal/domain/user/repository.go",
+				Source:      "features/oauth/oidc/internal/infrastructure/auth/oidc_oauth.go.tmpl",
+				Destination: "internal/infrastructure/auth/oidc_oauth.go",
 			},
-			// Domain: session
 			{
-				Source:      "features/auth/oauth2/internal/domain/session/model.go.tmpl",
-				Destination: "internal/domain/session/model.go",
+				Source:      "features/oauth/oidc/internal/infrastructure/auth/oidc_oauth_test.go.tmpl",
+				Destination: "internal/infrastructure/auth/oidc_oauth_test.go",
 			},
+		},
+	},
+	// --- Account ---
+	{
+		ID:          "account-2fa",
+		CategoryID:  CategoryAccount,
+		Name:        "Two-factor authentication",
+		Description: "TOTP authenticator apps with a server-rendered QR code, recovery codes and remembered devices.",
+		Tags:        []string{"account", "2fa"},
+		Routes: []string{
+			"GET /login/two-factor",
+			"POST /login/two-factor",
+			"GET /profile/two-factor",
+			"GET /profile/two-factor/setup",
+			"POST /profile/two-factor/setup",
+			"POST /profile/two-factor/recovery-codes",
+			"POST /profile/two-factor/disable",
+		},
+		Env: []string{
+			"TWO_FACTOR_ISSUER",
+			"TWO_FACTOR_ENCRYPTION_KEY",
+			"TWO_FACTOR_TRUSTED_DEVICE_TTL",
+		},
+		Directories: []string{
+			"db/migrations",
+			"internal/app/auth",
+			"internal/domain/twofactor",
+			"internal/infrastructure/persistence",
+			"internal/transport/http",
+			"web/templates/pages",
+		},
+		Templates: []Template{
 			{
-				Source:      "features/auth/oauth2/internal/domain/session/repository.go.tmpl",
-				Destination: "internal/domain/session/repository.go",
+				Source:      "features/account/two-factor/internal/domain/twofactor/totp.go.tmpl",
+				Destination: "internal/domain/twofactor/totp.go",
 			},
-			// Application
 			{
-				Source:      "features/auth/oauth2/internal/application/auth/service.go.tmpl",
-				Destination: "internal/app/auth/service.go",
+				Source:      "features/account/two-factor/internal/domain/twofactor/totp_test.go.tmpl",
+				Destination: "internal/domain/twofactor/totp_test.go",
 			},
 			{
-				Source:      "features/auth/oauth2/internal/application/auth/type.go.tmpl",
-				Destination: "internal/app/auth/type.go",
+				Source:      "features/account/two-factor/internal/domain/twofactor/recovery.go.tmpl",
+				Destination: "internal/domain/twofactor/recovery.go",
 			},
 			{
-				Source:      "features/auth/oauth2/internal/application/auth/ports.go.tmpl",
-				Destination: "internal/app/auth/ports.go",
+				Source:      "features/account/two-factor/internal/domain/twofactor/model.go.tmpl",
+				Destination: "internal/domain/twofactor/model.go",
 			},
-			// Infrastructure: persistence (user)
 			{
-				Source:      "features/auth/oauth2/internal/infrastructure/persistence/user_repository_sqlite.go.tmpl",
-				Destination: "internal/infrastructure/persistence/user_repository_sqlite.go",
+				Source:      "features/account/two-factor/internal/domain/twofactor/repository.go.tmpl",
+				Destination: "internal/domain/twofactor/repository.go",
 			},
 			{
-				Source:      "features/auth/oauth2/internal/infrastructure/persistence/tx.go.tmpl",
-				Destination: "internal/infrastructure/persistence/tx.go",
+				Source:      "features/account/two-factor/internal/application/auth/two_factor.go.tmpl",
+				Destination: "internal/app/auth/two_factor.go",
 			},
-			// Infrastructure: persistence (session)
 			{
-				Source:      "features/auth/oauth2/internal/infrastructure/persistence/session_repository_sqlite.go.tmpl",
-				Destination: "internal/infrastructure/persistence/session_repository_sqlite.go",
+				Source:      "features/account/two-factor/internal/application/auth/two_factor_test.go.tmpl",
+				Destination: "internal/app/auth/two_factor_test.go",
 			},
-			// Infrastructure: http cookie helpers
 			{
-				Source:      "features/auth/oauth2/internal/transport/http/cookies.go.tmpl",
-				Destination: "internal/transport/http/cookies.go",
+				Source:      "features/account/two-factor/internal/infrastructure/persistence/two_factor_repository_sqlite.go.tmpl",
+				Destination: "internal/infrastructure/persistence/two_factor_repository_sqlite.go",
 			},
-			// Migrations
 			{
-				Source:      "features/auth/oauth2/db/migrations/0001_create_users.sql.tmpl",
-				Destination: "db/migrations/0001_create_users.sql",
+				Source:      "features/account/two-factor/internal/transport/http/two_factor_handlers.go.tmpl",
+				Destination: "internal/transport/http/two_factor_handlers.go",
 			},
 			{
-				Source:      "features/auth/oauth2/db/migrations/0002_create_sessions.sql.tmpl",
-				Destination: "db/migrations/0002_create_sessions.sql",
+				Source:      "features/account/two-factor/web/templates/pages/two_factor.html.tmpl",
+				Destination: "web/templates/pages/two_factor.html",
+				Delims:      BracketDelims,
 			},
 			{
-				Source:      "features/auth/oauth2/db/migrations/0003_create_user_identities.sql.tmpl",
-				Destination: "db/migrations/0003_create_user_identities.sql",
+				Source:      "features/account/two-factor/db/migrations/0011_create_two_factor.sql.tmpl",
+				Destination: "db/migrations/0011_create_two_factor.sql",
 			},
 		},
 	},
 	{
-		ID:          "auth-magic-link",
-		CategoryID:  CategoryAuth,
-		Name:        "Magic Link",
-		Description: "Passwordless sign-in via one-time emailed link (logged in development).",
-		Tags:        []string{"auth", "magic-link", "passwordless"},
+		ID:          "account-roles",
+		CategoryID:  CategoryAccount,
+		Name:        "Roles and permissions",
+		Description: "Roles with permission sets, a seeded admin role, a RequirePermission middleware and a CLI to grant roles by email.",
+		Tags:        []string{"account", "roles"},
 		Directories: []string{
+			"cmd/roles",
 			"db/migrations",
-			"internal/app/auth",
-			"internal/domain/magiclink",
-			"internal/domain/session",
-			"internal/domain/user",
+			"internal/app/roles",
+			"internal/domain/role",
 			"internal/infrastructure/persistence",
 			"internal/transport/http",
-			"web/templates/pages",
 		},
 		Templates: []Template{
-			// HTTP transport
-			{
-				Source:      "features/auth/magic-link/internal/transport/http/auth_handlers.go.tmpl",
-				Destination: "internal/transport/http/auth_handlers.go",
-			},
-			{
-				Source:      "features/auth/magic-link/internal/transport/http/render.go.tmpl",
-				Destination: "internal/transport/http/render.go",
-			},
 			{
-				Source:      "features/auth/magic-link/internal/transport/http/auth_middleware.go.tmpl",
-				Destination: "internal/transport/http/auth_middleware.go",
+				Source:      "features/account/roles/internal/domain/role/model.go.tmpl",
+				Destination: "internal/domain/role/model.go",
 			},
 			{
-				Source:      "features/auth/magic-link/internal/transport/http/cookies.go.tmpl",
-				Destination: "internal/transport/http/cookies.go",
+				Source:      "features/account/roles/internal/domain/role/model_test.go.tmpl",
+				Destination: "internal/domain/role/model_test.go",
 			},
-			// Web templates
 			{
-				Source:      "features/auth/magic-link/web/templates/pages/profile.html.tmpl",
-				Destination: "web/templates/pages/profile.html",
+				Source:      "features/account/roles/internal/domain/role/repository.go.tmpl",
+				Destination: "internal/domain/role/repository.go",
 			},
 			{
-				Source:      "features/auth/magic-link/web/templates/pages/login.html.tmpl",
-				Destination: "web/templates/pages/login.html",
+				Source:      "features/account/roles/internal/application/roles/service.go.tmpl",
+				Destination: "internal/app/roles/service.go",
 			},
-			// Domain: user
 			{
-				Source:      "features/auth/magic-link/internal/domain/user/model.go.tmpl",
-				Destination: "internal/domain/user/model.go",
+				Source:      "features/account/roles/internal/application/roles/service_test.go.tmpl",
+				Destination: "internal/app/roles/service_test.go",
 			},
 			{
-				Source:      "features/auth/magic-link/internal/domain/user/repository.go.tmpl",
-				Destination: "internal/domain/user/repository.go",
+				Source:      "features/account/roles/internal/infrastructure/persistence/role_repository_sqlite.go.tmpl",
+				Destination: "internal/infrastructure/persistence/role_repository_sqlite.go",
 			},
-			// Domain: session
 			{
-				Source:      "features/auth/magic-link/internal/domain/session/model.go.tmpl",
-				Destination: "internal/domain/session/model.go",
+				Source:      "features/account/roles/internal/transport/http/role_middleware.go.tmpl",
+				Destination: "internal/transport/http/role_middleware.go",
 			},
 			{
-				Source:      "features/auth/magic-link/internal/domain/session/repository.go.tmpl",
-				Destination: "internal/domain/session/repository.go",
+				Source:      "features/account/roles/internal/transport/http/role_middleware_test.go.tmpl",
+				Destination: "internal/transport/http/role_middleware_test.go",
 			},
-			// Domain: magic link
 			{
-				Source:      "features/auth/magic-link/internal/domain/magiclink/model.go.tmpl",
-				Destination: "internal/domain/magiclink/model.go",
+				Source:      "features/account/roles/cmd/roles/main.go.tmpl",
+				Destination: "cmd/roles/main.go",
 			},
 			{
-				Source:      "features/auth/magic-link/internal/domain/magiclink/repository.go.tmpl",
-				Destination: "internal/domain/magiclink/repository.go",
+				Source:      "features/account/roles/db/migrations/0013_create_roles.sql.tmpl",
+				Destination: "db/migrations/0013_create_roles.sql",
 			},
-			// Application
+		},
+	},
+	{
+		ID:          "account-tenancy",
+		CategoryID:  CategoryAccount,
+		Name:        "Organizations",
+		Description: "Multi-tenant workspaces with member roles, email invitations, a navbar switcher and org-scoped queries.",
+		Tags:        []string{"account", "tenancy"},
+		Routes: []string{
+			"GET /orgs",
+			"POST /orgs/create",
+			"POST /orgs/switch",
+			"POST /orgs/invitations",
+			"POST /orgs/invitations/revoke",
+			"GET /orgs/invitations/accept",
+			"POST /orgs/members/remove",
+		},
+		Env: []string{
+			"TENANCY_BASE_URL",
+			"TENANCY_INVITATION_TTL",
+		},
+		Directories: []string{
+			"db/migrations",
+			"internal/app/organizations",
+			"internal/domain/organization",
+			"internal/infrastructure/persistence",
+			"internal/transport/http",
+			"web/templates/pages",
+		},
+		Templates: []Template{
 			{
-				Source:      "features/auth/magic-link/internal/application/auth/service.go.tmpl",
-				Destination: "internal/app/auth/service.go",
+				Source:      "features/account/tenancy/internal/domain/organization/model.go.tmpl",
+				Destination: "internal/domain/organization/model.go",
 			},
 			{
-				Source:      "features/auth/magic-link/internal/application/auth/type.go.tmpl",
-				Destination: "internal/app/auth/type.go",
+				Source:      "features/account/tenancy/internal/domain/organization/repository.go.tmpl",
+				Destination: "internal/domain/organization/repository.go",
 			},
 			{
-				Source:      "features/auth/magic-link/internal/application/auth/ports.go.tmpl",
-				Destination: "internal/app/auth/ports.go",
+				Source:      "features/account/tenancy/internal/application/organizations/service.go.tmpl",
+				Destination: "internal/app/organizations/service.go",
 			},
-			// Infrastructure: persistence
 			{
-				Source:      "features/auth/magic-link/internal/infrastructure/persistence/user_repository_sqlite.go.tmpl",
-				Destination: "internal/infrastructure/persistence/user_repository_sqlite.go",
+				Source:      "features/account/tenancy/internal/application/organizations/service_test.go.tmpl",
+				Destination: "internal/app/organizations/service_test.go",
 			},
 			{
-				Source:      "features/auth/magic-link/internal/infrastructure/persistence/session_repository_sqlite.go.tmpl",
-				Destination: "internal/infrastructure/persistence/session_repository_sqlite.go",
+				Source:      "features/account/tenancy/internal/infrastructure/persistence/organization_repository_sqlite.go.tmpl",
+				Destination: "internal/infrastructure/persistence/organization_repository_sqlite.go",
 			},
 			{
-				Source:      "features/auth/magic-link/internal/infrastructure/persistence/magic_link_token_repository_sqlite.go.tmpl",
-				Destination: "internal/infrastructure/persistence/magic_link_token_repository_sqlite.go",
+				Source:      "features/account/tenancy/internal/infrastructure/persistence/org_scope.go.tmpl",
+				Destination: "internal/infrastructure/persistence/org_scope.go",
 			},
 			{
-				Source:      "features/auth/magic-link/internal/infrastructure/persistence/tx.go.tmpl",
-				Destination: "internal/infrastructure/persistence/tx.go",
+				Source:      "features/account/tenancy/internal/transport/http/org_middleware.go.tmpl",
+				Destination: "internal/transport/http/org_middleware.go",
 			},
-			// Migrations
 			{
-				Source:      "features/auth/magic-link/db/migrations/0001_create_users.sql.tmpl",
-				Destination: "db/migrations/0001_create_users.sql",
+				Source:      "features/account/tenancy/internal/transport/http/org_handlers.go.tmpl",
+				Destination: "internal/transport/http/org_handlers.go",
 			},
 			{
-				Source:      "features/auth/magic-link/db/migrations/0002_create_sessions.sql.tmpl",
-				Destination: "db/migrations/0002_create_sessions.sql",
+				Source:      "features/account/tenancy/web/templates/pages/orgs.html.tmpl",
+				Destination: "web/templates/pages/orgs.html",
+				Delims:      BracketDelims,
 			},
 			{
-				Source:      "features/auth/magic-link/db/migrations/0003_create_magic_link_tokens.sql.tmpl",
-				Destination: "db/migrations/0003_create_magic_link_tokens.sql",
+				Source:      "features/account/tenancy/db/migrations/0014_create_organizations.sql.tmpl",
+				Destination: "db/migrations/0014_create_organizations.sql",
 			},
 		},
 	},
-	// --- OAuth Providers ---
 	{
-		ID:          "oauth-github",
-		CategoryID:  CategoryOAuthProviders,
-		Name:        "GitHub",
-		Description: "GitHub OAuth2 identity provider.",
-		Tags:        []string{"github"},
+		ID:          "account-api-tokens",
+		CategoryID:  CategoryAccount,
+		Name:        "API tokens",
+		Description: "Personal access tokens with scopes, expiry and last-used tracking, accepted as Authorization: Bearer on JSON endpoints.",
+		Tags:        []string{"account", "api-tokens"},
+		Routes: []string{
+			"GET /profile/api-tokens",
+			"POST /profile/api-tokens",
+			"POST /profile/api-tokens/revoke",
+			"GET /api/me",
+		},
 		Directories: []string{
-			"internal/infrastructure/auth",
+			"db/migrations",
+			"internal/app/auth",
+			"internal/domain/apitoken",
+			"internal/infrastructure/persistence",
+			"internal/transport/http",
+			"web/templates/pages",
 		},
 		Templates: []Template{
 			{
-				Source:      "features/oauth/github/internal/infrastructure/auth/github_oauth.go.tmpl",
-				Destination: "internal/infrastructure/auth/github_oauth.go",
+				Source:      "features/account/api-tokens/internal/domain/apitoken/model.go.tmpl",
+				Destination: "internal/domain/apitoken/model.go",
 			},
-		},
-	},
-	{
-		ID:          "oauth-google",
-		CategoryID:  CategoryOAuthProviders,
-		Name:        "Google",
-		Description: "Google OAuth2 identity provider.",
-		Tags:        []string{"google"},
-		Directories: []string{
-			"internal/infrastructure/auth",
-		},
-		Templates: []Template{
 			{
-				Source:      "features/oauth/google/internal/infrastructure/auth/google_oauth.go.tmpl",
-				Destination: "internal/infrastructure/auth/google_oauth.go",
+				Source:      "features/account/api-tokens/internal/domain/apitoken/repository.go.tmpl",
+				Destination: "internal/domain/apitoken/repository.go",
 			},
-		},
-	},
-	{
-		ID:          "oauth-yandex",
-		CategoryID:  CategoryOAuthProviders,
-		Name:        "Yandex",
-		Description: "Yandex OAuth2 identity provider.",
-		Tags:        []string{"yandex"},
-		Directories: []string{
-			"internal/infrastructure/auth",
-		},
-		Templates: []Template{
 			{
-				Source:      "features/oauth/yandex/internal/infrastructure/auth/yandex_oauth.go.tmpl",
-				Destination: "internal/infrastructure/auth/yandex_oauth.go",
+				Source:      "features/account/api-tokens/internal/application/auth/api_tokens.go.tmpl",
+				Destination: "internal/app/auth/api_tokens.go",
+			},
+			{
+				Source:      "features/account/api-tokens/internal/application/auth/api_tokens_test.go.tmpl",
+				Destination: "internal/app/auth/api_tokens_test.go",
+			},
+			{
+				Source:      "features/account/api-tokens/internal/infrastructure/persistence/api_token_repository_sqlite.go.tmpl",
+				Destination: "internal/infrastructure/persistence/api_token_repository_sqlite.go",
+			},
+			{
+				Source:      "features/account/api-tokens/internal/transport/http/api_token_middleware.go.tmpl",
+				Destination: "internal/transport/http/api_token_middleware.go",
+			},
+			{
+				Source:      "features/account/api-tokens/internal/transport/http/api_token_middleware_test.go.tmpl",
+				Destination: "internal/transport/http/api_token_middleware_test.go",
+			},
+			{
+				Source:      "features/account/api-tokens/internal/transport/http/api_token_handlers.go.tmpl",
+				Destination: "internal/transport/http/api_token_handlers.go",
+			},
+			{
+				Source:      "features/account/api-tokens/web/templates/pages/api_tokens.html.tmpl",
+				Destination: "web/templates/pages/api_tokens.html",
+				Delims:      BracketDelims,
+			},
+			{
+				Source:      "features/account/api-tokens/db/migrations/0015_create_api_tokens.sql.tmpl",
+				Destination: "db/migrations/0015_create_api_tokens.sql",
 			},
 		},
 	},
@@ -512,6 +991,13 @@ var featureCatalog = []Feature{
 		Name:        "SMTP",
 		Description: "Send email via SMTP with STARTTLS support.",
 		Tags:        []string{"email", "smtp"},
+		Env: []string{
+			"SMTP_HOST",
+			"SMTP_PORT",
+			"SMTP_USERNAME",
+			"SMTP_PASSWORD",
+			"SMTP_FROM",
+		},
 		Directories: []string{
 			"internal/app/email",
 			"internal/infrastructure/email",
@@ -540,47 +1026,178 @@ var featureCatalog = []Feature{
 		CategoryID:  CategoryPayments,
 		Name:        "YooKassa",
 		Description: "YooKassa checkout integration.",
-		Tags:        []string{"payments", "yookassa"},
+		Tags:        []string{"payments", "checkout", "yookassa"},
+		Routes:      paymentRoutes("yookassa"),
+		Env: append([]string{
+			"YOOKASSA_SHOP_ID",
+			"YOOKASSA_SECRET_KEY",
+			"YOOKASSA_RECEIPTS",
+			"YOOKASSA_TAX_SYSTEM_CODE",
+			"YOOKASSA_VAT_CODE",
+			"YOOKASSA_PAYMENT_SUBJECT",
+			"YOOKASSA_PAYMENT_MODE",
+			"PAYMENTS_HTTP_TIMEOUT",
+			"PAYMENTS_HTTP_RETRIES",
+		}, paymentEnv...),
+		Directories: paymentDirectories,
+		Templates: paymentTemplates(
+			Template{
+				Source:      "features/payments/common/internal/infrastructure/payments/httpclient.go.tmpl",
+				Destination: "internal/infrastructure/payments/httpclient.go",
+			},
+			Template{
+				Source:      "features/payments/common/internal/infrastructure/payments/httpclient_test.go.tmpl",
+				Destination: "internal/infrastructure/payments/httpclient_test.go",
+			},
+			Template{
+				Source:      "features/payments/yookassa/internal/infrastructure/payments/yookassa.go.tmpl",
+				Destination: "internal/infrastructure/payments/yookassa.go",
+			},
+			Template{
+				Source:      "features/payments/yookassa/internal/infrastructure/payments/yookassa_test.go.tmpl",
+				Destination: "internal/infrastructure/payments/yookassa_test.go",
+			},
+			Template{
+				Source:      "features/payments/yookassa/internal/transport/http/yookassa_webhook_test.go.tmpl",
+				Destination: "internal/transport/http/yookassa_webhook_test.go",
+			},
+		),
+	},
+	{
+		ID:          "payments-stripe",
+		CategoryID:  CategoryPayments,
+		Name:        "Stripe",
+		Description: "Stripe Checkout integration with signed webhooks.",
+		Tags:        []string{"payments", "checkout", "stripe"},
+		Routes:      paymentRoutes("stripe"),
+		Env: append([]string{
+			"STRIPE_SECRET_KEY",
+			"STRIPE_WEBHOOK_SECRET",
+			"PAYMENTS_HTTP_TIMEOUT",
+			"PAYMENTS_HTTP_RETRIES",
+		}, paymentEnv...),
+		Directories: paymentDirectories,
+		Templates: paymentTemplates(
+			Template{
+				Source:      "features/payments/common/internal/infrastructure/payments/httpclient.go.tmpl",
+				Destination: "internal/infrastructure/payments/httpclient.go",
+			},
+			Template{
+				Source:      "features/payments/common/internal/infrastructure/payments/httpclient_test.go.tmpl",
+				Destination: "internal/infrastructure/payments/httpclient_test.go",
+			},
+			Template{
+				Source:      "features/payments/stripe/internal/infrastructure/payments/stripe.go.tmpl",
+				Destination: "internal/infrastructure/payments/stripe.go",
+			},
+			Template{
+				Source:      "features/payments/stripe/internal/infrastructure/payments/stripe_test.go.tmpl",
+				Destination: "internal/infrastructure/payments/stripe_test.go",
+			},
+			Template{
+				Source:      "features/payments/stripe/internal/transport/http/stripe_webhook_test.go.tmpl",
+				Destination: "internal/transport/http/stripe_webhook_test.go",
+			},
+		),
+	},
+	{
+		ID:          "payments-fake",
+		CategoryID:  CategoryPayments,
+		Name:        "Fake (development)",
+		Description: "In-process payment provider with its own confirmation page for offline development and tests.",
+		Tags:        []string{"payments", "checkout", "fake"},
+		Routes: append(paymentRoutes("fake"),
+			"GET /payments/fake/{id}",
+			"POST /payments/fake/{id}",
+		),
+		Env:         paymentEnv,
+		Directories: paymentDirectories,
+		Templates: paymentTemplates(
+			Template{
+				Source:      "features/payments/fake/internal/infrastructure/payments/fake.go.tmpl",
+				Destination: "internal/infrastructure/payments/fake.go",
+			},
+			Template{
+				Source:      "features/payments/fake/internal/transport/http/fake_payment_handlers.go.tmpl",
+				Destination: "internal/transport/http/fake_payment_handlers.go",
+				Delims:      BracketDelims,
+			},
+			Template{
+				Source:      "features/payments/fake/internal/transport/http/fake_checkout_test.go.tmpl",
+				Destination: "internal/transport/http/fake_checkout_test.go",
+			},
+		),
+	},
+	// --- Billing ---
+	{
+		ID:          "billing-none",
+		CategoryID:  CategoryBilling,
+		Name:        "None",
+		Description: "Skip recurring billing.",
+		Tags:        []string{"billing"},
+	},
+	{
+		ID:          "billing-subscriptions",
+		CategoryID:  CategoryBilling,
+		Name:        "Subscriptions",
+		Description: "Plans, saved payment methods, renewals with grace periods and dunning emails.",
+		Tags:        []string{"billing", "subscriptions"},
+		Routes: []string{
+			"GET /billing",
+			"POST /billing/subscribe",
+			"POST /billing/cancel",
+			"POST /billing/resume",
+		},
+		Env: []string{
+			"SUBSCRIPTIONS_RENEWAL_INTERVAL",
+			"SUBSCRIPTIONS_RETRY_INTERVAL",
+			"SUBSCRIPTIONS_GRACE_PERIOD",
+		},
 		Directories: []string{
 			"db/migrations",
-			"internal/domain/payment",
-			"internal/infrastructure/payments",
+			"internal/app/subscriptions",
+			"internal/domain/subscription",
 			"internal/infrastructure/persistence",
 			"internal/transport/http",
 			"web/templates/pages",
 		},
 		Templates: []Template{
 			{
-				Source:      "features/payments/yookassa/internal/domain/payment/model.go.tmpl",
-				Destination: "internal/domain/payment/model.go",
+				Source:      "features/billing/subscriptions/db/migrations/0006_create_subscriptions.sql.tmpl",
+				Destination: "db/migrations/0006_create_subscriptions.sql",
 			},
 			{
-				Source:      "features/payments/yookassa/internal/domain/payment/repository.go.tmpl",
-				Destination: "internal/domain/payment/repository.go",
+				Source:      "features/billing/subscriptions/internal/domain/subscription/model.go.tmpl",
+				Destination: "internal/domain/subscription/model.go",
 			},
 			{
-				Source:      "features/payments/yookassa/internal/infrastructure/payments/yookassa.go.tmpl",
-				Destination: "internal/infrastructure/payments/yookassa.go",
+				Source:      "features/billing/subscriptions/internal/domain/subscription/model_test.go.tmpl",
+				Destination: "internal/domain/subscription/model_test.go",
 			},
 			{
-				Source:      "features/payments/yookassa/internal/infrastructure/persistence/payment_repository_sqlite.go.tmpl",
-				Destination: "internal/infrastructure/persistence/payment_repository_sqlite.go",
+				Source:      "features/billing/subscriptions/internal/domain/subscription/repository.go.tmpl",
+				Destination: "internal/domain/subscription/repository.go",
 			},
 			{
-				Source:      "features/payments/yookassa/internal/transport/http/payment_handlers.go.tmpl",
-				Destination: "internal/transport/http/payment_handlers.go",
+				Source:      "features/billing/subscriptions/internal/application/subscriptions/service.go.tmpl",
+				Destination: "internal/app/subscriptions/service.go",
 			},
 			{
-				Source:      "features/payments/yookassa/web/templates/pages/checkout.html.tmpl",
-				Destination: "web/templates/pages/checkout.html",
+				Source:      "features/billing/subscriptions/internal/application/subscriptions/service_test.go.tmpl",
+				Destination: "internal/app/subscriptions/service_test.go",
 			},
 			{
-				Source:      "features/payments/yookassa/web/templates/pages/payment_success.html.tmpl",
-				Destination: "web/templates/pages/payment_success.html",
+				Source:      "features/billing/subscriptions/internal/infrastructure/persistence/subscription_repository_sqlite.go.tmpl",
+				Destination: "internal/infrastructure/persistence/subscription_repository_sqlite.go",
 			},
 			{
-				Source:      "features/payments/yookassa/db/migrations/0004_create_payments.sql.tmpl",
-				Destination: "db/migrations/0004_create_payments.sql",
+				Source:      "features/billing/subscriptions/internal/transport/http/billing_handlers.go.tmpl",
+				Destination: "internal/transport/http/billing_handlers.go",
+			},
+			{
+				Source:      "features/billing/subscriptions/web/templates/pages/billing.html.tmpl",
+				Destination: "web/templates/pages/billing.html",
+				Delims:      BracketDelims,
 			},
 		},
 	},
@@ -598,6 +1215,12 @@ var featureCatalog = []Feature{
 		Name:        "Ansible",
 		Description: "Ansible playbook for Ubuntu VPS with Caddy reverse proxy.",
 		Tags:        []string{"deploy", "ansible"},
+		MakeTargets: []string{
+			"deploy",
+		},
+		Tools: []string{
+			"ansible-playbook",
+		},
 		Directories: []string{
 			"deploy/templates",
 			"deploy/group_vars",
@@ -610,14 +1233,17 @@ var featureCatalog = []Feature{
 			{
 				Source:      "features/deploy/ansible/deploy/playbook.yml.tmpl",
 				Destination: "deploy/playbook.yml",
+				Delims:      BracketDelims,
 			},
 			{
 				Source:      "features/deploy/ansible/deploy/templates/app.service.j2.tmpl",
 				Destination: "deploy/templates/app.service.j2",
+				Verbatim:    true,
 			},
 			{
 				Source:      "features/deploy/ansible/deploy/templates/Caddyfile.j2.tmpl",
 				Destination: "deploy/templates/Caddyfile.j2",
+				Verbatim:    true,
 			},
 			{
 				Source:      "features/deploy/ansible/deploy/group_vars/all.yml.tmpl",
@@ -634,18 +1260,282 @@ var defaultSelection = Selection{
 	CategoryDatabase:       {"database-none"},
 	CategoryAuth:           {"auth-none"},
 	CategoryOAuthProviders: {},
+	CategoryAccount:        {},
 	CategoryEmail:          {"email-none"},
 	CategoryPayments:       {"payments-none"},
+	CategoryBilling:        {"billing-none"},
 	CategoryDeploy:         {"deploy-none"},
 }
 
 var featureDependencies = map[string][]string{
 	"auth-oauth2":       {"database-sqlite"},
 	"auth-magic-link":   {"database-sqlite"},
+	"auth-password":     {"database-sqlite"},
+	"auth-passkeys":     {"database-sqlite"},
 	"oauth-github":      {"auth-oauth2"},
 	"oauth-google":      {"auth-oauth2"},
 	"oauth-yandex":      {"auth-oauth2"},
+	"oauth-oidc":        {"auth-oauth2"},
 	"payments-yookassa": {"database-sqlite"},
+	"payments-stripe":   {"database-sqlite"},
+	"payments-fake":     {"database-sqlite"},
+}
+
+// featureTagRequirements lists, per feature, the categories in which one of
+// the selected features must carry a tag. It covers needs that several
+// features satisfy, such as "any sign-in method".
+var featureTagRequirements = map[string]map[string]string{
+	"account-2fa": {
+		CategoryAuth: "accounts",
+	},
+	"account-roles": {
+		CategoryAuth: "accounts",
+	},
+	"account-tenancy": {
+		CategoryAuth: "accounts",
+	},
+	"account-api-tokens": {
+		CategoryAuth: "accounts",
+	},
+	"billing-subscriptions": {
+		CategoryAuth:     "accounts",
+		CategoryPayments: "checkout",
+	},
+}
+
+// paymentEnv lists the variables shared by every payment provider.
+var paymentEnv = []string{
+	"PAYMENTS_RETURN_URL",
+	"PAYMENTS_CURRENCY",
+	"PAYMENTS_LOCALE",
+}
+
+var paymentDirectories = []string{
+	"db/migrations",
+	"internal/app/payments",
+	"internal/domain/payment",
+	"internal/infrastructure/payments",
+	"internal/infrastructure/persistence",
+	"internal/transport/http",
+	"web/templates/pages",
+}
+
+// paymentRoutes lists the checkout and admin routes plus the provider's
+// webhook endpoint.
+func paymentRoutes(provider string) []string {
+	return []string{
+		"GET /payments/checkout",
+		"POST /payments/checkout",
+		"GET /payments/success",
+		"GET /payments/purchases",
+		"POST /webhooks/" + provider,
+		"GET /admin/payments",
+		"POST /admin/payments/capture",
+		"POST /admin/payments/cancel",
+		"POST /admin/payments/refund",
+	}
+}
+
+// authTemplates returns the account core shared by every sign-in method
+// (users, sessions, cookies, middleware, login and profile pages) followed by
+// the method's own templates. Selecting several methods registers the core
+// once; Compose skips the identical copies.
+func authTemplates(flow ...Template) []Template {
+	common := []Template{
+		{
+			Source:      "features/auth/common/internal/transport/http/auth_handlers.go.tmpl",
+			Destination: "internal/transport/http/auth_handlers.go",
+		},
+		{
+			Source:      "features/auth/common/internal/transport/http/render.go.tmpl",
+			Destination: "internal/transport/http/render.go",
+		},
+		{
+			Source:      "features/auth/common/internal/transport/http/auth_middleware.go.tmpl",
+			Destination: "internal/transport/http/auth_middleware.go",
+		},
+		{
+			Source:      "features/auth/common/internal/transport/http/cookies.go.tmpl",
+			Destination: "internal/transport/http/cookies.go",
+		},
+		{
+			Source:      "features/auth/common/internal/transport/http/session_handlers.go.tmpl",
+			Destination: "internal/transport/http/session_handlers.go",
+		},
+		{
+			Source:      "features/auth/common/internal/transport/http/session_handlers_test.go.tmpl",
+			Destination: "internal/transport/http/session_handlers_test.go",
+		},
+		{
+			Source:      "features/auth/common/web/templates/pages/profile.html.tmpl",
+			Destination: "web/templates/pages/profile.html",
+			Delims:      BracketDelims,
+		},
+		{
+			Source:      "features/auth/common/web/templates/pages/login.html.tmpl",
+			Destination: "web/templates/pages/login.html",
+			Delims:      BracketDelims,
+		},
+		{
+			Source:      "features/auth/common/web/templates/pages/sessions.html.tmpl",
+			Destination: "web/templates/pages/sessions.html",
+			Delims:      BracketDelims,
+		},
+		{
+			Source:      "features/auth/common/internal/domain/user/model.go.tmpl",
+			Destination: "internal/domain/user/model.go",
+		},
+		{
+			Source:      "features/auth/common/internal/domain/user/repository.go.tmpl",
+			Destination: "internal/domain/user/repository.go",
+		},
+		{
+			Source:      "features/auth/common/internal/domain/session/model.go.tmpl",
+			Destination: "internal/domain/session/model.go",
+		},
+		{
+			Source:      "features/auth/common/internal/domain/session/repository.go.tmpl",
+			Destination: "internal/domain/session/repository.go",
+		},
+		{
+			Source:      "features/auth/common/internal/application/auth/service.go.tmpl",
+			Destination: "internal/app/auth/service.go",
+		},
+		{
+			Source:      "features/auth/common/internal/application/auth/sessions.go.tmpl",
+			Destination: "internal/app/auth/sessions.go",
+		},
+		{
+			Source:      "features/auth/common/internal/application/auth/type.go.tmpl",
+			Destination: "internal/app/auth/type.go",
+		},
+		{
+			Source:      "features/auth/common/internal/application/auth/ports.go.tmpl",
+			Destination: "internal/app/auth/ports.go",
+		},
+		{
+			Source:      "features/auth/common/internal/application/auth/service_test.go.tmpl",
+			Destination: "internal/app/auth/service_test.go",
+		},
+		{
+			Source:      "features/auth/common/internal/infrastructure/persistence/user_repository_sqlite.go.tmpl",
+			Destination: "internal/infrastructure/persistence/user_repository_sqlite.go",
+		},
+		{
+			Source:      "features/auth/common/internal/infrastructure/persistence/session_repository_sqlite.go.tmpl",
+			Destination: "internal/infrastructure/persistence/session_repository_sqlite.go",
+		},
+		{
+			Source:      "features/auth/common/db/migrations/0001_create_users.sql.tmpl",
+			Destination: "db/migrations/0001_create_users.sql",
+		},
+		{
+			Source:      "features/auth/common/db/migrations/0002_create_sessions.sql.tmpl",
+			Destination: "db/migrations/0002_create_sessions.sql",
+		},
+	}
+	return append(common, flow...)
+}
+
+// paymentTemplates returns the provider-neutral payment templates (gateway
+// port, domain, persistence, handlers, pages, migrations) followed by the
+// provider's own gateway implementation.
+func paymentTemplates(provider ...Template) []Template {
+	common := []Template{
+		{
+			Source:      "features/payments/common/internal/application/payments/gateway.go.tmpl",
+			Destination: "internal/app/payments/gateway.go",
+		},
+		{
+			Source:      "features/payments/common/internal/application/payments/receipt.go.tmpl",
+			Destination: "internal/app/payments/receipt.go",
+		},
+		{
+			Source:      "features/payments/common/internal/domain/payment/model.go.tmpl",
+			Destination: "internal/domain/payment/model.go",
+		},
+		{
+			Source:      "features/payments/common/internal/domain/payment/money.go.tmpl",
+			Destination: "internal/domain/payment/money.go",
+		},
+		{
+			Source:      "features/payments/common/internal/domain/payment/money_test.go.tmpl",
+			Destination: "internal/domain/payment/money_test.go",
+		},
+		{
+			Source:      "features/payments/common/internal/domain/payment/event.go.tmpl",
+			Destination: "internal/domain/payment/event.go",
+		},
+		{
+			Source:      "features/payments/common/internal/domain/payment/product.go.tmpl",
+			Destination: "internal/domain/payment/product.go",
+		},
+		{
+			Source:      "features/payments/common/internal/domain/payment/refund.go.tmpl",
+			Destination: "internal/domain/payment/refund.go",
+		},
+		{
+			Source:      "features/payments/common/internal/domain/payment/repository.go.tmpl",
+			Destination: "internal/domain/payment/repository.go",
+		},
+		{
+			Source:      "features/payments/common/internal/infrastructure/persistence/payment_repository_sqlite.go.tmpl",
+			Destination: "internal/infrastructure/persistence/payment_repository_sqlite.go",
+		},
+		{
+			Source:      "features/payments/common/internal/transport/http/payment_handlers.go.tmpl",
+			Destination: "internal/transport/http/payment_handlers.go",
+		},
+		{
+			Source:      "features/payments/common/internal/transport/http/payment_admin_handlers.go.tmpl",
+			Destination: "internal/transport/http/payment_admin_handlers.go",
+		},
+		{
+			Source:      "features/payments/common/web/templates/pages/checkout.html.tmpl",
+			Destination: "web/templates/pages/checkout.html",
+			Delims:      BracketDelims,
+		},
+		{
+			Source:      "features/payments/common/web/templates/pages/payment_success.html.tmpl",
+			Destination: "web/templates/pages/payment_success.html",
+			Delims:      BracketDelims,
+		},
+		{
+			Source:      "features/payments/common/web/templates/pages/purchases.html.tmpl",
+			Destination: "web/templates/pages/purchases.html",
+			Delims:      BracketDelims,
+		},
+		{
+			Source:      "features/payments/common/web/templates/pages/payments_admin.html.tmpl",
+			Destination: "web/templates/pages/payments_admin.html",
+			Delims:      BracketDelims,
+		},
+		{
+			Source:      "features/payments/common/db/migrations/0004_create_payments.sql.tmpl",
+			Destination: "db/migrations/0004_create_payments.sql",
+		},
+		{
+			Source:      "features/payments/common/db/migrations/0005_create_payment_events.sql.tmpl",
+			Destination: "db/migrations/0005_create_payment_events.sql",
+		},
+		{
+			Source:      "features/payments/common/db/migrations/0007_create_refunds.sql.tmpl",
+			Destination: "db/migrations/0007_create_refunds.sql",
+		},
+		{
+			Source:      "features/payments/common/db/migrations/0008_create_products.sql.tmpl",
+			Destination: "db/migrations/0008_create_products.sql",
+		},
+		{
+			Source:      "features/payments/common/db/migrations/0016_make_payments_provider_neutral.sql.tmpl",
+			Destination: "db/migrations/0016_make_payments_provider_neutral.sql",
+		},
+		{
+			Source:      "features/payments/common/db/migrations/0017_store_payment_times_as_datetime.sql.tmpl",
+			Destination: "db/migrations/0017_store_payment_times_as_datetime.sql",
+		},
+	}
+	return append(common, provider...)
 }
 
 // Categories returns a copy of the registered feature categories ordered for display.
@@ -690,6 +1580,42 @@ func FeatureByID(id string) (Feature, bool) {
 	return Feature{}, false
 }
 
+// FeatureDependents returns the IDs of features that depend on the given feature.
+func FeatureDependents(id string) []string {
+	dependents := make([]string, 0)
+	for featureID, deps := range featureDependencies {
+		for _, dep := range deps {
+			if dep == id {
+				dependents = append(dependents, featureID)
+				break
+			}
+		}
+	}
+	sort.Strings(dependents)
+	return dependents
+}
+
+// FeatureAlternatives returns, per category, the features of which at least
+// one must be selected together with the feature.
+func FeatureAlternatives(id string) map[string][]string {
+	requirements := featureTagRequirements[id]
+	if len(requirements) == 0 {
+		return nil
+	}
+	out := make(map[string][]string, len(requirements))
+	for categoryID, tag := range requirements {
+		for _, feature := range FeaturesForCategory(categoryID) {
+			for _, featureTag := range feature.Tags {
+				if featureTag == tag {
+					out[categoryID] = append(out[categoryID], feature.ID)
+					break
+				}
+			}
+		}
+	}
+	return out
+}
+
 // FeatureDependencies returns the IDs required by a feature.
 func FeatureDependencies(id string) []string {
 	deps := featureDependencies[id]
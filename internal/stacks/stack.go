@@ -28,6 +28,15 @@ func (s Stack) HasFeature(id string) bool {
 	return false
 }
 
+// Selection reconstructs the feature selection the stack was composed from.
+func (s Stack) Selection() Selection {
+	sel := make(Selection, len(s.Features))
+	for _, feature := range s.Features {
+		sel[feature.CategoryID] = append(sel[feature.CategoryID], feature.ID)
+	}
+	return sel
+}
+
 // Template describes a templated file sourced from the embedded filesystem.
 type Template struct {
 	// Source is the path inside internal/templates that should be rendered.
@@ -36,8 +45,27 @@ type Template struct {
 	Destination string
 	// Mode controls the filesystem permissions for the generated file.
 	Mode fsFileMode
+	// Feature is the ID of the feature contributing the template. Compose fills
+	// it in; base templates leave it empty.
+	Feature string
+	// Delims replaces the {{ }} action delimiters for sources that are
+	// themselves templates, such as html/template pages or Jinja files, so
+	// their own actions can be written as is. The zero value keeps {{ }}.
+	Delims Delims
+	// Verbatim copies the source unchanged instead of rendering it.
+	Verbatim bool
+}
+
+// Delims are the action delimiters of a template source.
+type Delims struct {
+	Left  string
+	Right string
 }
 
+// BracketDelims marks scaffolding-time actions as [[ ]] and leaves {{ }} to
+// the generated file.
+var BracketDelims = Delims{Left: "[[", Right: "]]"}
+
 // FeatureCategory represents a group of compatible modular features.
 type FeatureCategory struct {
 	ID            string
@@ -56,6 +84,14 @@ type Feature struct {
 	Templates   []Template
 	Directories []string
 	Tags        []string
+	// Routes lists the HTTP routes the feature registers ("METHOD /path").
+	Routes []string
+	// Env lists the environment variables the feature reads.
+	Env []string
+	// MakeTargets lists the Makefile targets the feature adds.
+	MakeTargets []string
+	// Tools lists external programs the feature expects during development or deployment.
+	Tools []string
 }
 
 // Selection captures the chosen feature identifiers per category.
@@ -92,7 +128,13 @@ func Compose(sel Selection) (Stack, error) {
 			tagSet[tag] = struct{}{}
 		}
 		for _, tmpl := range feature.Templates {
+			tmpl.Feature = feature.ID
 			if existing, ok := tmplSet[tmpl.Destination]; ok {
+				if existing.Source == tmpl.Source {
+					// Shared template registered by several selected features
+					// (e.g. the auth core); the first feature keeps ownership.
+					continue
+				}
 				return Stack{}, fmt.Errorf("conflicting template destination %q between %s and %s", tmpl.Destination, existing.Source, tmpl.Source)
 			}
 			tmplSet[tmpl.Destination] = tmpl
@@ -195,6 +237,13 @@ func resolveSelection(sel Selection) ([]resolvedFeature, error) {
 		if !category.AllowMultiple && len(ids) > 1 {
 			return nil, fmt.Errorf("multiple selections provided for single-choice category %q", category.Name)
 		}
+		if len(ids) > 1 {
+			for _, id := range ids {
+				if strings.HasSuffix(id, "-none") {
+					return nil, fmt.Errorf("feature %q cannot be combined with other selections in category %q", id, category.Name)
+				}
+			}
+		}
 
 		availableFeatures := FeaturesForCategory(category.ID)
 		availableIDs := featureIDs(availableFeatures)
@@ -250,6 +299,23 @@ func resolveSelection(sel Selection) ([]resolvedFeature, error) {
 		}
 	}
 
+	for _, selectedFeatures := range selectedByCategory {
+		for _, selected := range selectedFeatures {
+			for categoryID, alternatives := range FeatureAlternatives(selected.ID) {
+				satisfied := false
+				for _, id := range alternatives {
+					if containsFeature(selectedByCategory[categoryID], id) {
+						satisfied = true
+						break
+					}
+				}
+				if !satisfied {
+					return nil, fmt.Errorf("feature %q requires one of %s in category %q", selected.ID, strings.Join(alternatives, ", "), categoryIndex[categoryID].Name)
+				}
+			}
+		}
+	}
+
 	if containsFeature(selectedByCategory[CategoryAuth], "auth-oauth2") && len(selectedByCategory[CategoryOAuthProviders]) == 0 {
 		return nil, fmt.Errorf("feature %q requires at least one selection in category %q", "auth-oauth2", categoryIndex[CategoryOAuthProviders].Name)
 	}
@@ -363,13 +429,20 @@ func featureIndex() map[string]Feature {
 }
 
 // SelectionFromIDs normalizes per-category input ensuring each value is tracked as a slice.
+// A comma-separated value selects several features of a multi-choice category.
 func SelectionFromIDs(values map[string]string) Selection {
 	selection := make(Selection, len(values))
 	for key, value := range values {
-		if strings.TrimSpace(value) == "" {
+		ids := make([]string, 0, 1)
+		for _, id := range strings.Split(value, ",") {
+			if id = strings.TrimSpace(id); id != "" {
+				ids = append(ids, id)
+			}
+		}
+		if len(ids) == 0 {
 			continue
 		}
-		selection[key] = []string{value}
+		selection[key] = ids
 	}
 	return selection
 }
@@ -134,3 +134,236 @@ func TestComposeAllowsMagicLinkWithSQLite(t *testing.T) {
 		t.Fatal("expected composed stack to include auth-magic-link")
 	}
 }
+
+func TestComposeCombinesOAuth2AndMagicLink(t *testing.T) {
+	t.Parallel()
+
+	sel := Selection{
+		CategoryFrontend:       {"frontend-htmx"},
+		CategoryStyling:        {"styling-tailwind"},
+		CategoryHTTP:           {"http-standard"},
+		CategoryDatabase:       {"database-sqlite"},
+		CategoryAuth:           {"auth-oauth2", "auth-magic-link"},
+		CategoryOAuthProviders: {"oauth-github"},
+	}
+
+	stack, err := Compose(sel)
+	if err != nil {
+		t.Fatalf("expected compose to succeed, got: %v", err)
+	}
+
+	owners := map[string]string{}
+	for _, tmpl := range stack.Templates {
+		owners[tmpl.Destination] = tmpl.Feature
+	}
+	for dest, want := range map[string]string{
+		"internal/app/auth/service.go":                    "auth-oauth2",
+		"internal/app/auth/oauth.go":                      "auth-oauth2",
+		"internal/app/auth/magic_link.go":                 "auth-magic-link",
+		"db/migrations/0009_create_magic_link_tokens.sql": "auth-magic-link",
+	} {
+		if owners[dest] != want {
+			t.Fatalf("expected %s to be owned by %s, got %q", dest, want, owners[dest])
+		}
+	}
+}
+
+func TestComposeCombinesPasswordAndMagicLink(t *testing.T) {
+	t.Parallel()
+
+	sel := Selection{
+		CategoryFrontend: {"frontend-htmx"},
+		CategoryStyling:  {"styling-tailwind"},
+		CategoryHTTP:     {"http-standard"},
+		CategoryDatabase: {"database-sqlite"},
+		CategoryAuth:     {"auth-magic-link", "auth-password"},
+	}
+
+	stack, err := Compose(sel)
+	if err != nil {
+		t.Fatalf("expected compose to succeed, got: %v", err)
+	}
+
+	owners := map[string]string{}
+	for _, tmpl := range stack.Templates {
+		owners[tmpl.Destination] = tmpl.Feature
+	}
+	for dest, want := range map[string]string{
+		"internal/app/auth/magic_link.go":                    "auth-magic-link",
+		"internal/app/auth/password.go":                      "auth-password",
+		"internal/domain/password/common_passwords.txt":      "auth-password",
+		"db/migrations/0010_create_password_credentials.sql": "auth-password",
+		"web/templates/pages/password.html":                  "auth-password",
+	} {
+		if owners[dest] != want {
+			t.Fatalf("expected %s to be owned by %s, got %q", dest, want, owners[dest])
+		}
+	}
+}
+
+func TestComposeAddsPasskeysToOtherSignInMethods(t *testing.T) {
+	t.Parallel()
+
+	sel := Selection{
+		CategoryFrontend: {"frontend-htmx"},
+		CategoryStyling:  {"styling-tailwind"},
+		CategoryHTTP:     {"http-chi"},
+		CategoryAuth:     {"auth-passkeys"},
+	}
+	if err := ValidateSelection(sel); err == nil || !strings.Contains(err.Error(), "database-sqlite") {
+		t.Fatalf("expected passkeys to require sqlite, got: %v", err)
+	}
+
+	sel[CategoryDatabase] = []string{"database-sqlite"}
+	sel[CategoryAuth] = []string{"auth-password", "auth-passkeys"}
+	stack, err := Compose(sel)
+	if err != nil {
+		t.Fatalf("expected compose to succeed, got: %v", err)
+	}
+
+	owners := map[string]string{}
+	for _, tmpl := range stack.Templates {
+		owners[tmpl.Destination] = tmpl.Feature
+	}
+	for dest, want := range map[string]string{
+		"internal/app/auth/password.go":          "auth-password",
+		"internal/app/auth/passkeys.go":          "auth-passkeys",
+		"db/migrations/0012_create_passkeys.sql": "auth-passkeys",
+		"public/assets/scripts/passkeys.js":      "auth-passkeys",
+		"web/templates/pages/passkeys.html":      "auth-passkeys",
+	} {
+		if owners[dest] != want {
+			t.Fatalf("expected %s to be owned by %s, got %q", dest, want, owners[dest])
+		}
+	}
+}
+
+func TestValidateSelectionRejectsNoneCombinedWithOtherFeatures(t *testing.T) {
+	t.Parallel()
+
+	sel := Selection{
+		CategoryFrontend: {"frontend-htmx"},
+		CategoryStyling:  {"styling-tailwind"},
+		CategoryHTTP:     {"http-standard"},
+		CategoryDatabase: {"database-sqlite"},
+		CategoryAuth:     {"auth-none", "auth-magic-link"},
+	}
+
+	err := ValidateSelection(sel)
+	if err == nil || !strings.Contains(err.Error(), "cannot be combined") {
+		t.Fatalf("expected a none exclusivity error, got: %v", err)
+	}
+}
+
+func TestSelectionFromIDsSplitsCommaSeparatedValues(t *testing.T) {
+	t.Parallel()
+
+	sel := SelectionFromIDs(map[string]string{
+		CategoryAuth:  "auth-oauth2, auth-magic-link",
+		CategoryHTTP:  "http-chi",
+		CategoryEmail: " ",
+	})
+	if got := strings.Join(sel[CategoryAuth], ","); got != "auth-oauth2,auth-magic-link" {
+		t.Fatalf("unexpected auth selection %q", got)
+	}
+	if got := strings.Join(sel[CategoryHTTP], ","); got != "http-chi" {
+		t.Fatalf("unexpected http selection %q", got)
+	}
+	if _, ok := sel[CategoryEmail]; ok {
+		t.Fatal("expected blank values to be skipped")
+	}
+}
+
+func TestComposePaymentProvidersShareGatewayTemplates(t *testing.T) {
+	t.Parallel()
+
+	for _, provider := range []string{"payments-yookassa", "payments-stripe", "payments-fake"} {
+		sel := Selection{
+			CategoryFrontend: {"frontend-htmx"},
+			CategoryStyling:  {"styling-tailwind"},
+			CategoryHTTP:     {"http-standard"},
+			CategoryDatabase: {"database-sqlite"},
+			CategoryPayments: {provider},
+		}
+
+		stack, err := Compose(sel)
+		if err != nil {
+			t.Fatalf("%s: expected compose to succeed, got: %v", provider, err)
+		}
+
+		found := false
+		for _, tmpl := range stack.Templates {
+			if tmpl.Destination == "internal/app/payments/gateway.go" {
+				found = tmpl.Feature == provider
+			}
+		}
+		if !found {
+			t.Fatalf("%s: expected the gateway port to be owned by the provider", provider)
+		}
+	}
+}
+
+func TestValidateSelectionRequiresAccountsAndCheckoutForSubscriptions(t *testing.T) {
+	t.Parallel()
+
+	sel := Selection{
+		CategoryFrontend: {"frontend-htmx"},
+		CategoryStyling:  {"styling-tailwind"},
+		CategoryHTTP:     {"http-standard"},
+		CategoryDatabase: {"database-sqlite"},
+		CategoryAuth:     {"auth-magic-link"},
+		CategoryBilling:  {"billing-subscriptions"},
+	}
+
+	err := ValidateSelection(sel)
+	if err == nil || !strings.Contains(err.Error(), "in category \"Payments\"") {
+		t.Fatalf("expected a payments requirement error, got: %v", err)
+	}
+
+	sel[CategoryPayments] = []string{"payments-fake"}
+	if _, err := Compose(sel); err != nil {
+		t.Fatalf("expected compose to succeed, got: %v", err)
+	}
+}
+
+func TestValidateSelectionRequiresSignInForTwoFactor(t *testing.T) {
+	t.Parallel()
+
+	sel := Selection{
+		CategoryFrontend: {"frontend-htmx"},
+		CategoryStyling:  {"styling-tailwind"},
+		CategoryHTTP:     {"http-standard"},
+		CategoryDatabase: {"database-sqlite"},
+		CategoryAccount:  {"account-2fa"},
+	}
+
+	err := ValidateSelection(sel)
+	if err == nil || !strings.Contains(err.Error(), "auth-password") {
+		t.Fatalf("expected a sign-in requirement error, got: %v", err)
+	}
+
+	sel[CategoryAuth] = []string{"auth-password"}
+	stack, err := Compose(sel)
+	if err != nil {
+		t.Fatalf("expected compose to succeed, got: %v", err)
+	}
+	found := false
+	for _, tmpl := range stack.Templates {
+		if tmpl.Destination == "db/migrations/0011_create_two_factor.sql" {
+			found = tmpl.Feature == "account-2fa"
+		}
+	}
+	if !found {
+		t.Fatal("expected account-2fa to add the two-factor migration")
+	}
+}
+
+func TestFeatureDependentsListsReverseDependencies(t *testing.T) {
+	t.Parallel()
+
+	got := FeatureDependents("auth-oauth2")
+	want := []string{"oauth-github", "oauth-google", "oauth-oidc", "oauth-yandex"}
+	if strings.Join(got, ",") != strings.Join(want, ",") {
+		t.Fatalf("expected %v, got %v", want, got)
+	}
+}
@@ -46,10 +46,16 @@ type multiSelectBinding struct {
 	category stacks.FeatureCategory
 	choices  []stacks.Feature
 	values   []string
+	// visible reports whether the category applies to the current answers;
+	// hidden categories contribute nothing to the selection.
+	visible func() bool
 }
 
 const sqliteFeatureID = "database-sqlite"
 
+// combinedAuthValue selects both account-based sign-in methods.
+const combinedAuthValue = "auth-oauth2,auth-magic-link"
+
 // Run executes the wizard and returns the user's selections.
 func Run(opts Options, input io.Reader, output io.Writer) (Result, error) {
 	if len(opts.Categories) == 0 {
@@ -128,15 +134,46 @@ func Run(opts Options, input io.Reader, output io.Writer) (Result, error) {
 		if authBinding == nil {
 			return false
 		}
-		return strings.TrimSpace(authBinding.value) == "auth-oauth2"
+		for _, id := range strings.Split(authBinding.value, ",") {
+			if strings.TrimSpace(id) == "auth-oauth2" {
+				return true
+			}
+		}
+		return false
+	}
+	accountsEnabled := func() bool {
+		return authEnabled() && authBinding != nil && strings.TrimSpace(authBinding.value) != "auth-none"
+	}
+	var paymentsBinding *featureBinding
+	billingEnabled := func() bool {
+		if !authEnabled() || authBinding == nil || paymentsBinding == nil {
+			return false
+		}
+		return strings.TrimSpace(authBinding.value) != "auth-none" && strings.TrimSpace(paymentsBinding.value) != "payments-none"
+	}
+	// skipped reports whether a hidden category should keep its default.
+	skipped := func(binding *featureBinding) bool {
+		switch binding.category.ID {
+		case stacks.CategoryAuth:
+			return !authEnabled()
+		case stacks.CategoryBilling:
+			return !billingEnabled()
+		}
+		return false
 	}
 
 	for _, category := range opts.Categories {
 		choices := opts.FeatureChoices[category.ID]
 
-		if category.AllowMultiple && category.ID == stacks.CategoryOAuthProviders {
-			// Multi-select for OAuth providers
-			mBinding := &multiSelectBinding{category: category, choices: choices}
+		if category.AllowMultiple && category.ID != stacks.CategoryAuth {
+			// Multi-select for OAuth providers and account features
+			mBinding := &multiSelectBinding{category: category, choices: choices, visible: func() bool { return true }}
+			switch category.ID {
+			case stacks.CategoryOAuthProviders:
+				mBinding.visible = oauth2Selected
+			case stacks.CategoryAccount:
+				mBinding.visible = accountsEnabled
+			}
 			defaults := defaultSelection[category.ID]
 			if len(defaults) > 0 {
 				mBinding.values = defaults
@@ -160,7 +197,7 @@ func Run(opts Options, input io.Reader, output io.Writer) (Result, error) {
 
 			group := huh.NewGroup(multiSelect)
 			group.WithHideFunc(func() bool {
-				return !oauth2Selected()
+				return !mBinding.visible()
 			})
 
 			groups = append(groups, group)
@@ -170,7 +207,20 @@ func Run(opts Options, input io.Reader, output io.Writer) (Result, error) {
 
 		binding := &featureBinding{category: category, choices: choices}
 
+		options := make([]huh.Option[string], 0, len(choices)+1)
+		for _, feature := range choices {
+			options = append(options, huh.NewOption(feature.Name, feature.ID))
+		}
+		if category.ID == stacks.CategoryAuth {
+			// Sign-in methods can be combined; offer the pairing that shares
+			// one account between OAuth providers and magic links.
+			options = append(options, huh.NewOption("OAuth2 + Magic Link", combinedAuthValue))
+		}
+
 		defaultID := first(defaultSelection[category.ID])
+		if len(defaultSelection[category.ID]) > 1 {
+			defaultID = strings.Join(defaultSelection[category.ID], ",")
+		}
 		if defaultID != "" {
 			for _, feature := range choices {
 				if feature.ID == defaultID {
@@ -179,15 +229,13 @@ func Run(opts Options, input io.Reader, output io.Writer) (Result, error) {
 				}
 			}
 		}
+		if binding.value == "" && defaultID == combinedAuthValue && category.ID == stacks.CategoryAuth {
+			binding.value = combinedAuthValue
+		}
 		if binding.value == "" {
 			binding.value = choices[0].ID
 		}
 
-		options := make([]huh.Option[string], 0, len(choices))
-		for _, feature := range choices {
-			options = append(options, huh.NewOption(feature.Name, feature.ID))
-		}
-
 		selectField := huh.NewSelect[string]().
 			Title(stepLabel(step, totalSteps, category.Name)).
 			Options(options...).
@@ -210,6 +258,9 @@ func Run(opts Options, input io.Reader, output io.Writer) (Result, error) {
 		if category.ID == stacks.CategoryAuth {
 			authBinding = binding
 		}
+		if category.ID == stacks.CategoryPayments {
+			paymentsBinding = binding
+		}
 
 		group := huh.NewGroup(selectField)
 		if category.ID == stacks.CategoryAuth {
@@ -229,6 +280,11 @@ func Run(opts Options, input io.Reader, output io.Writer) (Result, error) {
 				return !authEnabled()
 			})
 		}
+		if category.ID == stacks.CategoryBilling {
+			group.WithHideFunc(func() bool {
+				return !billingEnabled()
+			})
+		}
 
 		groups = append(groups, group)
 		step++
@@ -282,7 +338,7 @@ func Run(opts Options, input io.Reader, output io.Writer) (Result, error) {
 
 		selected := make(map[string]string, len(bindings))
 		for _, binding := range bindings {
-			if binding.category.ID == stacks.CategoryAuth && !authEnabled() {
+			if skipped(binding) {
 				continue
 			}
 			selected[binding.category.ID] = binding.value
@@ -291,7 +347,7 @@ func Run(opts Options, input io.Reader, output io.Writer) (Result, error) {
 		selection := stacks.SelectionFromIDs(selected)
 		// Add multi-select values
 		for _, mb := range multiBindings {
-			if oauth2Selected() && len(mb.values) > 0 {
+			if mb.visible() && len(mb.values) > 0 {
 				selection[mb.category.ID] = mb.values
 			}
 		}
@@ -309,7 +365,7 @@ func Run(opts Options, input io.Reader, output io.Writer) (Result, error) {
 
 		featureBlocksAdded := false
 		for _, binding := range bindings {
-			if binding.category.ID == stacks.CategoryAuth && !authEnabled() {
+			if skipped(binding) {
 				continue
 			}
 			if strings.TrimSpace(binding.value) == "" {
@@ -330,7 +386,7 @@ func Run(opts Options, input io.Reader, output io.Writer) (Result, error) {
 			lines = append(lines, fmt.Sprintf("  %s: %s", categoryName, binding.value))
 		}
 		for _, mb := range multiBindings {
-			if oauth2Selected() && len(mb.values) > 0 {
+			if mb.visible() && len(mb.values) > 0 {
 				if !featureBlocksAdded {
 					lines = append(lines, "")
 					lines = append(lines, "Selections")
@@ -405,11 +461,9 @@ func Run(opts Options, input io.Reader, output io.Writer) (Result, error) {
 		outputDir = suggestOutputDir(appName)
 	}
 
-	authAvailable := authEnabled()
-
 	selection := make(map[string]string, len(bindings))
 	for _, binding := range bindings {
-		if binding.category.ID == stacks.CategoryAuth && !authAvailable {
+		if skipped(binding) {
 			continue
 		}
 		selection[binding.category.ID] = binding.value
@@ -417,9 +471,9 @@ func Run(opts Options, input io.Reader, output io.Writer) (Result, error) {
 
 	result := stacks.SelectionFromIDs(selection)
 
-	// Add multi-select OAuth providers
+	// Add multi-select OAuth providers and account features
 	for _, mb := range multiBindings {
-		if oauth2Selected() && len(mb.values) > 0 {
+		if mb.visible() && len(mb.values) > 0 {
 			result[mb.category.ID] = mb.values
 		}
 	}